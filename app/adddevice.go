@@ -2,6 +2,8 @@ package app
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
@@ -10,10 +12,57 @@ import (
 	zone "github.com/lrstanley/bubblezone"
 	"github.com/pdrolopes/syncthing_TUI/styles"
 	"github.com/pdrolopes/syncthing_TUI/syncthing"
+	"github.com/samber/lo"
 )
 
 var tabLabels = []string{"General", "Sharing", "Advanced"}
 
+var compressionOptions = []string{"metadata", "always", "never"}
+
+// items of the Advanced tab, the numeric inputs come last
+const (
+	advancedIntroducer = iota
+	advancedAutoAccept
+	advancedUntrusted
+	advancedCompression
+	advancedMaxRecvKbps
+	advancedMaxSendKbps
+	advancedNumConnections
+	advancedItems
+)
+
+var advancedLabels = []string{
+	"Introducer",
+	"Auto Accept Folders",
+	"Untrusted",
+	"Compression",
+	"Max Recv Rate KiB/s",
+	"Max Send Rate KiB/s",
+	"Connections",
+}
+
+const (
+	ADD_DEVICE_MIN_WIDTH  = 40
+	ADD_DEVICE_MAX_WIDTH  = 100
+	ADD_DEVICE_MIN_HEIGHT = 10
+	ADD_DEVICE_MAX_HEIGHT = 24
+	// tabs row and bottom border plus a line of margin above and below
+	ADD_DEVICE_FRAME_HEIGHT = 6
+	// side borders plus a column of margin on each side
+	ADD_DEVICE_FRAME_WIDTH = 4
+)
+
+// addDeviceModalSize fits the modal content in the terminal, within bounds that
+// keep it readable. Small terminals still get the minimum size.
+func addDeviceModalSize(terminalWidth, terminalHeight int) (int, int) {
+	width := max(ADD_DEVICE_MIN_WIDTH,
+		min(terminalWidth-ADD_DEVICE_FRAME_WIDTH, ADD_DEVICE_MAX_WIDTH))
+	height := max(ADD_DEVICE_MIN_HEIGHT,
+		min(terminalHeight-ADD_DEVICE_FRAME_HEIGHT, ADD_DEVICE_MAX_HEIGHT))
+
+	return width, height
+}
+
 type AddDeviceModel struct {
 	Show            bool
 	existingDevice  bool
@@ -21,24 +70,32 @@ type AddDeviceModel struct {
 	deviceIdInput   textinput.Model
 	deviceNameInput textinput.Model
 	zonePrefix      string
+	folders         []FolderViewModel
+	sharedFolders   map[string]struct{}
+	folderCursor    int
+	putConfig       PutConfig
+	advancedCursor  int
+	// max recv, max send and connections, in the advancedItems order
+	advancedInputs []textinput.Model
+	err            error
 
-	httpData            HttpData
-	width               int
-	height              int
-	introducer          bool
-	autoAccept          bool
-	addresses           []string
-	maxRecvKbps         int64
-	maxSendKbps         int64
-	untrusted           bool
-	numberOfConnections int
-	compression         string
+	httpData    HttpData
+	width       int
+	height      int
+	introducer  bool
+	autoAccept  bool
+	addresses   []string
+	untrusted   bool
+	compression string
 }
 
 func NewPendingDevice(
 	deviceName, deviceID string,
 	deviceDefaults syncthing.DeviceDefaults,
+	folders []FolderViewModel,
 	httpData HttpData,
+	putConfig PutConfig,
+	width, height int,
 ) AddDeviceModel {
 	deviceIdInput := textinput.New()
 	deviceIdInput.SetValue(deviceID)
@@ -48,26 +105,194 @@ func NewPendingDevice(
 	deviceNameInput.SetValue(deviceName)
 	deviceNameInput.Focus()
 	deviceNameInput.CharLimit = 50
+
+	advancedInputs := lo.Map([]int64{
+		deviceDefaults.MaxRecvKbps,
+		deviceDefaults.MaxSendKbps,
+		int64(deviceDefaults.NumConnections),
+	}, func(value int64, index int) textinput.Model {
+		input := textinput.New()
+		input.CharLimit = 10
+		input.Width = 12
+		input.Placeholder = "0"
+		input.SetValue(strconv.FormatInt(value, 10))
+		return input
+	})
+
+	compression := deviceDefaults.Compression
+	if !lo.Contains(compressionOptions, compression) {
+		compression = compressionOptions[0]
+	}
+
 	return AddDeviceModel{
 		Show:           true,
 		existingDevice: true,
 		zonePrefix:     zone.NewPrefix(),
+		folders:        folders,
+		sharedFolders:  make(map[string]struct{}),
 		httpData:       httpData,
+		putConfig:      putConfig,
+
+		width:           width,
+		height:          height,
+		deviceNameInput: deviceNameInput,
+		deviceIdInput:   deviceIdInput,
+		untrusted:       false,
+		autoAccept:      deviceDefaults.AutoAcceptFolders,
+		introducer:      deviceDefaults.Introducer,
+		compression:     compression,
+		addresses:       deviceDefaults.Addresses,
+		advancedInputs:  advancedInputs,
+	}
+}
+
+func (m AddDeviceModel) Resize(width, height int) AddDeviceModel {
+	m.width = width
+	m.height = height
+	return m
+}
+
+func (m AddDeviceModel) folderMark(i int) string {
+	return fmt.Sprintf("%sfolder/%d", m.zonePrefix, i)
+}
+
+// setTab switches tabs, the inputs only keep the focus on the General tab so
+// typing elsewhere doesn't change them.
+func (m AddDeviceModel) setTab(i int) (AddDeviceModel, tea.Cmd) {
+	m.activeTab = (i + len(tabLabels)) % len(tabLabels)
+	if m.activeTab == 0 {
+		return m, m.deviceNameInput.Focus()
+	}
 
-		// TODO figure out good values for dimensions, reflect terminal size?
-		width:               80,
-		height:              16,
-		deviceNameInput:     deviceNameInput,
-		deviceIdInput:       deviceIdInput,
-		untrusted:           false,
-		autoAccept:          deviceDefaults.AutoAcceptFolders,
-		introducer:          deviceDefaults.Introducer,
-		compression:         deviceDefaults.Compression,
-		addresses:           deviceDefaults.Addresses,
-		maxSendKbps:         deviceDefaults.MaxSendKbps,
-		maxRecvKbps:         deviceDefaults.MaxRecvKbps,
-		numberOfConnections: deviceDefaults.NumConnections,
+	m.deviceIdInput.Blur()
+	m.deviceNameInput.Blur()
+	if m.activeTab == 2 {
+		return m.setAdvancedCursor(m.advancedCursor)
 	}
+	for i := range m.advancedInputs {
+		m.advancedInputs[i].Blur()
+	}
+	return m, nil
+}
+
+func (m AddDeviceModel) advancedMark(i int) string {
+	return fmt.Sprintf("%sadvanced/%d", m.zonePrefix, i)
+}
+
+// setAdvancedCursor moves over the Advanced tab items, focusing the numeric
+// input under the cursor.
+func (m AddDeviceModel) setAdvancedCursor(i int) (AddDeviceModel, tea.Cmd) {
+	m.advancedCursor = max(0, min(i, advancedItems-1))
+	for j := range m.advancedInputs {
+		m.advancedInputs[j].Blur()
+	}
+	if m.advancedCursor < advancedMaxRecvKbps {
+		return m, nil
+	}
+
+	return m, m.advancedInputs[m.advancedCursor-advancedMaxRecvKbps].Focus()
+}
+
+// toggleAdvanced flips the boolean options and cycles the compression.
+func (m AddDeviceModel) toggleAdvanced(i int) AddDeviceModel {
+	switch i {
+	case advancedIntroducer:
+		m.introducer = !m.introducer
+	case advancedAutoAccept:
+		m.autoAccept = !m.autoAccept
+	case advancedUntrusted:
+		m.untrusted = !m.untrusted
+	case advancedCompression:
+		index := lo.IndexOf(compressionOptions, m.compression)
+		m.compression = compressionOptions[(index+1)%len(compressionOptions)]
+	}
+
+	return m
+}
+
+func isDigits(s string) bool {
+	return strings.TrimLeft(s, "0123456789") == ""
+}
+
+func (m AddDeviceModel) inputFocused() bool {
+	return m.deviceIdInput.Focused() || m.deviceNameInput.Focused() ||
+		lo.SomeBy(m.advancedInputs, func(input textinput.Model) bool { return input.Focused() })
+}
+
+func (m AddDeviceModel) toggleFolder(i int) AddDeviceModel {
+	if i < 0 || i >= len(m.folders) {
+		return m
+	}
+
+	m.folderCursor = i
+	folderID := m.folders[i].Config.ID
+	if _, ok := m.sharedFolders[folderID]; ok {
+		delete(m.sharedFolders, folderID)
+	} else {
+		m.sharedFolders[folderID] = struct{}{}
+	}
+
+	return m
+}
+
+func (m AddDeviceModel) save() (AddDeviceModel, tea.Cmd) {
+	values := make([]int64, len(m.advancedInputs))
+	for i, input := range m.advancedInputs {
+		value, err := strconv.ParseInt(strings.TrimSpace(input.Value()), 10, 64)
+		if err != nil || value < 0 {
+			m.err = fmt.Errorf("%s must be a non-negative integer",
+				advancedLabels[advancedMaxRecvKbps+i])
+			m.advancedCursor = advancedMaxRecvKbps + i
+			m, _ = m.setTab(2)
+			return m, nil
+		}
+		values[i] = value
+	}
+
+	m.Show = false
+	device := syncthing.DeviceConfig{
+		DeviceID:          strings.TrimSpace(m.deviceIdInput.Value()),
+		Name:              strings.TrimSpace(m.deviceNameInput.Value()),
+		AutoAcceptFolders: m.autoAccept,
+		Addresses:         m.addresses,
+		Compression:       m.compression,
+		Introducer:        m.introducer,
+		MaxRecvKbps:       values[0],
+		MaxSendKbps:       values[1],
+		NumConnections:    int(values[2]),
+		Untrusted:         m.untrusted,
+	}
+	cmd := PostDeviceConfig(m.httpData, device)
+	if len(m.sharedFolders) == 0 || m.putConfig == nil {
+		return m, cmd
+	}
+
+	sharedFolders := m.sharedFolders
+	shareFolders := m.putConfig(m.httpData, func(config syncthing.Config) syncthing.Config {
+		// the closured config predates the device post, a PUT without it removes it
+		if !lo.ContainsBy(config.Devices, func(d syncthing.DeviceConfig) bool {
+			return d.DeviceID == device.DeviceID
+		}) {
+			config.Devices = append(slices.Clone(config.Devices), device)
+		}
+
+		folders := make([]syncthing.FolderConfig, len(config.Folders))
+		for i, folder := range config.Folders {
+			_, shared := sharedFolders[folder.ID]
+			if shared && !lo.ContainsBy(folder.Devices, func(d syncthing.FolderDevice) bool {
+				return d.DeviceID == device.DeviceID
+			}) {
+				folder.Devices = append(slices.Clone(folder.Devices),
+					syncthing.FolderDevice{DeviceID: device.DeviceID})
+			}
+			folders[i] = folder
+		}
+		config.Folders = folders
+
+		return config
+	})
+
+	return m, tea.Sequence(cmd, shareFolders)
 }
 
 func (m AddDeviceModel) Init() tea.Cmd {
@@ -87,13 +312,46 @@ func (m AddDeviceModel) Update(msg tea.Msg) (AddDeviceModel, tea.Cmd) {
 	case tea.KeyMsg:
 		switch {
 		case msg.String() == "q":
-			if !m.deviceIdInput.Focused() && !m.deviceNameInput.Focused() {
+			if !m.inputFocused() {
 				m.Show = false
 				return m, nil
 			}
 		case msg.Type == tea.KeyEsc:
 			m.Show = false
 			return m, nil
+		case msg.Type == tea.KeyTab:
+			return m.setTab(m.activeTab + 1)
+		case msg.Type == tea.KeyShiftTab:
+			return m.setTab(m.activeTab - 1)
+		}
+
+		if m.activeTab == 1 {
+			switch msg.String() {
+			case "down", "j":
+				m.folderCursor = min(m.folderCursor+1, len(m.folders)-1)
+			case "up", "k":
+				m.folderCursor = max(m.folderCursor-1, 0)
+			case " ":
+				m = m.toggleFolder(m.folderCursor)
+			}
+			return m, nil
+		}
+
+		if m.activeTab == 2 {
+			switch {
+			case msg.Type == tea.KeyDown:
+				return m.setAdvancedCursor(m.advancedCursor + 1)
+			case msg.Type == tea.KeyUp:
+				return m.setAdvancedCursor(m.advancedCursor - 1)
+			case m.advancedCursor < advancedMaxRecvKbps:
+				if msg.Type == tea.KeySpace || msg.Type == tea.KeyEnter {
+					return m.toggleAdvanced(m.advancedCursor), nil
+				}
+				return m, nil
+			case msg.Type == tea.KeyRunes && !isDigits(string(msg.Runes)):
+				// the rate limits and connections only take digits
+				return m, nil
+			}
 		}
 
 	case tea.MouseMsg:
@@ -118,36 +376,41 @@ func (m AddDeviceModel) Update(msg tea.Msg) (AddDeviceModel, tea.Cmd) {
 		}
 
 		if zone.Get(m.zonePrefix + "save").InBounds(msg) {
-			m.Show = false
-			cmd := PostDeviceConfig(m.httpData, syncthing.DeviceConfig{
-				DeviceID:          strings.TrimSpace(m.deviceIdInput.Value()),
-				Name:              strings.TrimSpace(m.deviceNameInput.Value()),
-				AutoAcceptFolders: m.autoAccept,
-				Addresses:         m.addresses,
-				Compression:       m.compression,
-				Introducer:        m.introducer,
-				MaxRecvKbps:       m.maxRecvKbps,
-				MaxSendKbps:       m.maxSendKbps,
-				NumConnections:    m.numberOfConnections,
-				Untrusted:         m.untrusted,
-			})
-			return m, cmd
+			return m.save()
 		}
 
 		for i := range tabLabels {
 			if zone.Get(fmt.Sprintf("tab-click/%d", i)).InBounds(msg) {
-				m.activeTab = i
-				break
+				return m.setTab(i)
+			}
+		}
+
+		for i := range m.folders {
+			if zone.Get(m.folderMark(i)).InBounds(msg) {
+				return m.toggleFolder(i), nil
+			}
+		}
+
+		for i := range advancedItems {
+			if zone.Get(m.advancedMark(i)).InBounds(msg) {
+				m, cmd := m.setAdvancedCursor(i)
+				return m.toggleAdvanced(i), cmd
 			}
 		}
 
 		return m, nil
 	}
-	var cmd1 tea.Cmd
-	var cmd2 tea.Cmd
-	m.deviceIdInput, cmd1 = m.deviceIdInput.Update(msg)
-	m.deviceNameInput, cmd2 = m.deviceNameInput.Update(msg)
-	return m, tea.Batch(cmd1, cmd2)
+	cmds := make([]tea.Cmd, 0, 2+len(m.advancedInputs))
+	var cmd tea.Cmd
+	m.deviceIdInput, cmd = m.deviceIdInput.Update(msg)
+	cmds = append(cmds, cmd)
+	m.deviceNameInput, cmd = m.deviceNameInput.Update(msg)
+	cmds = append(cmds, cmd)
+	for i := range m.advancedInputs {
+		m.advancedInputs[i], cmd = m.advancedInputs[i].Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	return m, tea.Batch(cmds...)
 }
 
 func (m AddDeviceModel) View() string {
@@ -217,11 +480,57 @@ func (m AddDeviceModel) viewGeneral() string {
 }
 
 func (m AddDeviceModel) viewSharing() string {
-	return "todo"
+	if len(m.folders) == 0 {
+		return "No folders to share"
+	}
+
+	rows := lo.Map(m.folders, func(folder FolderViewModel, i int) string {
+		_, shared := m.sharedFolders[folder.Config.ID]
+		row := fmt.Sprintf("%s %s",
+			lo.Ternary(shared, "[x]", "[ ]"),
+			lo.Ternary(folder.Config.Label != "", folder.Config.Label, folder.Config.ID))
+		if i == m.folderCursor {
+			row = lipgloss.NewStyle().Reverse(true).Render(row)
+		}
+		return zone.Mark(m.folderMark(i), row)
+	})
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		append([]string{"Share Folders With Device", ""}, rows...)...)
 }
 
 func (m AddDeviceModel) viewAdvanced() string {
-	return "todo"
+	values := []string{
+		lo.Ternary(m.introducer, "Yes", "No"),
+		lo.Ternary(m.autoAccept, "Yes", "No"),
+		lo.Ternary(m.untrusted, "Yes", "No"),
+		m.compression,
+	}
+	t := spaceAroundTable().Width(m.width - 2)
+	for i, label := range advancedLabels {
+		if i == m.advancedCursor {
+			label = lipgloss.NewStyle().Reverse(true).Render(label)
+		}
+		var value string
+		if i < advancedMaxRecvKbps {
+			value = styles.BtnStyleV2.Render(values[i])
+		} else {
+			value = m.advancedInputs[i-advancedMaxRecvKbps].View()
+		}
+		t = t.Row(label, zone.Mark(m.advancedMark(i), value))
+	}
+
+	rows := []string{
+		t.Render(),
+		"",
+		lipgloss.NewStyle().Italic(true).Render("0 means unlimited rates and default connections."),
+	}
+	if m.err != nil {
+		rows = append(rows, "",
+			lipgloss.NewStyle().Foreground(styles.ErrorColor).Render(m.err.Error()))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
 }
 
 func (m AddDeviceModel) viewActions() string {
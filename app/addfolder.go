@@ -0,0 +1,359 @@
+package app
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+	"github.com/pdrolopes/syncthing_TUI/syncthing"
+	"github.com/samber/lo"
+)
+
+var addFolderTabLabels = []string{"General", "Sharing"}
+
+const (
+	FOLDER_ID_ALPHABET = "abcdefghijklmnopqrstuvwxyz0123456789"
+	// syncthing generates IDs like "abcde-fghij"
+	FOLDER_ID_PART = 5
+)
+
+const (
+	addFolderLabelInput = iota
+	addFolderIDInput
+	addFolderPathInput
+	addFolderInputs
+)
+
+// NewFolder holds the fields set when adding a folder, syncthing fills the rest
+// from its folder defaults.
+type NewFolder struct {
+	ID      string                   `json:"id"`
+	Label   string                   `json:"label"`
+	Path    string                   `json:"path"`
+	Devices []syncthing.FolderDevice `json:"devices"`
+}
+
+type AddFolderModel struct {
+	Show       bool
+	activeTab  int
+	inputs     []textinput.Model
+	focus      int
+	devices    []DeviceViewModel
+	shared     map[string]struct{}
+	existing   []string
+	thisDevice string
+	err        error
+	zonePrefix string
+
+	httpData HttpData
+	width    int
+	height   int
+}
+
+func NewAddFolder(
+	defaults syncthing.FolderDefaults,
+	existingFolders []FolderViewModel,
+	devices []DeviceViewModel,
+	thisDevice string,
+	httpData HttpData,
+	width, height int,
+) AddFolderModel {
+	inputs := make([]textinput.Model, addFolderInputs)
+	for i := range inputs {
+		inputs[i] = textinput.New()
+	}
+	inputs[addFolderLabelInput].CharLimit = 50
+	inputs[addFolderLabelInput].SetValue(defaults.Label)
+	inputs[addFolderIDInput].CharLimit = 63
+	inputs[addFolderIDInput].SetValue(randomFolderID())
+	inputs[addFolderPathInput].SetValue(defaults.Path)
+	inputs[addFolderLabelInput].Focus()
+
+	shared := make(map[string]struct{})
+	for _, device := range defaults.Devices {
+		shared[device.DeviceID] = struct{}{}
+	}
+
+	return AddFolderModel{
+		Show:   true,
+		inputs: inputs,
+		devices: lo.Filter(devices, func(d DeviceViewModel, index int) bool {
+			return d.Config.DeviceID != thisDevice
+		}),
+		shared: shared,
+		existing: lo.Map(existingFolders, func(f FolderViewModel, index int) string {
+			return f.Config.ID
+		}),
+		thisDevice: thisDevice,
+		zonePrefix: zone.NewPrefix(),
+		httpData:   httpData,
+		width:      width,
+		height:     height,
+	}
+}
+
+// FromOffer pre-fills the form to accept a folder offered by a remote device.
+func (m AddFolderModel) FromOffer(folderID, label, deviceID string) AddFolderModel {
+	m.inputs[addFolderIDInput].SetValue(folderID)
+	if label != "" {
+		m.inputs[addFolderLabelInput].SetValue(label)
+	}
+	m.shared[deviceID] = struct{}{}
+	return m
+}
+
+func randomFolderID() string {
+	var id strings.Builder
+	for i := range 2 * FOLDER_ID_PART {
+		if i == FOLDER_ID_PART {
+			id.WriteByte('-')
+		}
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(FOLDER_ID_ALPHABET))))
+		if err != nil {
+			// the ID stays editable, an empty one is caught on save
+			return ""
+		}
+		id.WriteByte(FOLDER_ID_ALPHABET[n.Int64()])
+	}
+
+	return id.String()
+}
+
+func (m AddFolderModel) Resize(width, height int) AddFolderModel {
+	m.width = width
+	m.height = height
+	return m
+}
+
+func (m AddFolderModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m AddFolderModel) tabMark(i int) string {
+	return fmt.Sprintf("%stab/%d", m.zonePrefix, i)
+}
+
+func (m AddFolderModel) inputMark(i int) string {
+	return fmt.Sprintf("%sinput/%d", m.zonePrefix, i)
+}
+
+func (m AddFolderModel) deviceMark(i int) string {
+	return fmt.Sprintf("%sdevice/%d", m.zonePrefix, i)
+}
+
+// setFocus moves the focus over the inputs and then the devices, switching to the
+// tab holding the focused item.
+func (m AddFolderModel) setFocus(i int) (AddFolderModel, tea.Cmd) {
+	m.focus = (i + addFolderInputs + len(m.devices)) % (addFolderInputs + len(m.devices))
+	for j := range m.inputs {
+		m.inputs[j].Blur()
+	}
+	if m.focus >= addFolderInputs {
+		m.activeTab = 1
+		return m, nil
+	}
+
+	m.activeTab = 0
+	return m, m.inputs[m.focus].Focus()
+}
+
+func (m AddFolderModel) toggleDevice(i int) AddFolderModel {
+	deviceID := m.devices[i].Config.DeviceID
+	if _, ok := m.shared[deviceID]; ok {
+		delete(m.shared, deviceID)
+	} else {
+		m.shared[deviceID] = struct{}{}
+	}
+
+	return m
+}
+
+func (m AddFolderModel) validate() (NewFolder, int, error) {
+	folder := NewFolder{
+		ID:    strings.TrimSpace(m.inputs[addFolderIDInput].Value()),
+		Label: strings.TrimSpace(m.inputs[addFolderLabelInput].Value()),
+		Path:  strings.TrimSpace(m.inputs[addFolderPathInput].Value()),
+	}
+	if folder.ID == "" {
+		return folder, addFolderIDInput, errors.New("folder ID can't be empty")
+	}
+	if lo.Contains(m.existing, folder.ID) {
+		return folder, addFolderIDInput, fmt.Errorf("a folder with ID %q already exists", folder.ID)
+	}
+	if folder.Path == "" {
+		return folder, addFolderPathInput, errors.New("folder path can't be empty")
+	}
+
+	// syncthing adds this device on its own when it isn't known yet
+	if m.thisDevice != "" {
+		folder.Devices = append(folder.Devices, syncthing.FolderDevice{DeviceID: m.thisDevice})
+	}
+	for _, device := range m.devices {
+		if _, ok := m.shared[device.Config.DeviceID]; ok {
+			folder.Devices = append(folder.Devices,
+				syncthing.FolderDevice{DeviceID: device.Config.DeviceID})
+		}
+	}
+
+	return folder, 0, nil
+}
+
+func (m AddFolderModel) save() (AddFolderModel, tea.Cmd) {
+	folder, input, err := m.validate()
+	if err != nil {
+		m.err = err
+		return m.setFocus(input)
+	}
+
+	m.Show = false
+	return m, putFolder(m.httpData, folder)
+}
+
+func (m AddFolderModel) Update(msg tea.Msg) (AddFolderModel, tea.Cmd) {
+	if !m.Show {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyEsc:
+			m.Show = false
+			return m, nil
+		case tea.KeyTab, tea.KeyDown:
+			return m.setFocus(m.focus + 1)
+		case tea.KeyShiftTab, tea.KeyUp:
+			return m.setFocus(m.focus - 1)
+		case tea.KeyEnter:
+			return m.save()
+		case tea.KeySpace:
+			if m.focus >= addFolderInputs {
+				return m.toggleDevice(m.focus - addFolderInputs), nil
+			}
+		}
+	case tea.MouseMsg:
+		if msg.Action != tea.MouseActionRelease || msg.Button != tea.MouseButtonLeft {
+			return m, nil
+		}
+
+		if zone.Get(m.zonePrefix + "close").InBounds(msg) {
+			m.Show = false
+			return m, nil
+		}
+
+		if zone.Get(m.zonePrefix + "save").InBounds(msg) {
+			return m.save()
+		}
+
+		for i := range addFolderTabLabels {
+			if zone.Get(m.tabMark(i)).InBounds(msg) {
+				return m.setFocus(lo.Ternary(i == 0, addFolderLabelInput, addFolderInputs))
+			}
+		}
+
+		for i := range m.inputs {
+			if zone.Get(m.inputMark(i)).InBounds(msg) {
+				return m.setFocus(i)
+			}
+		}
+
+		for i := range m.devices {
+			if zone.Get(m.deviceMark(i)).InBounds(msg) {
+				m, _ = m.setFocus(addFolderInputs + i)
+				return m.toggleDevice(i), nil
+			}
+		}
+
+		return m, nil
+	}
+
+	if m.focus >= addFolderInputs {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.inputs[m.focus], cmd = m.inputs[m.focus].Update(msg)
+	return m, cmd
+}
+
+func (m AddFolderModel) View() string {
+	tabViews := lo.Map(addFolderTabLabels, func(label string, i int) string {
+		return zone.Mark(m.tabMark(i), lo.Ternary(i == m.activeTab, activeTab, tab).Render(label))
+	})
+	tabs := lipgloss.JoinHorizontal(lipgloss.Top, tabViews...)
+	gap := tabGap.Render(strings.Repeat(" ", max(0, m.width-lipgloss.Width(tabs))))
+	header := lipgloss.JoinHorizontal(lipgloss.Bottom, tabs, gap)
+
+	containerRest := tab.BorderTop(false).Padding(1, 1).Width(m.width).Height(m.height)
+	innerWidth := containerRest.GetWidth() - containerRest.GetHorizontalPadding()
+	footer := []string{m.viewActions()}
+	if m.err != nil {
+		errStyle := lipgloss.NewStyle().Foreground(styles.ErrorColor).Width(innerWidth)
+		footer = append([]string{errStyle.Render(m.err.Error()), ""}, footer...)
+	}
+	actions := lipgloss.PlaceHorizontal(
+		innerWidth,
+		lipgloss.Right,
+		lipgloss.JoinVertical(lipgloss.Right, footer...),
+	)
+	contentHeight := m.height - containerRest.GetVerticalPadding() - lipgloss.Height(actions)
+	var content string
+	switch m.activeTab {
+	case 0:
+		content = lipgloss.PlaceVertical(contentHeight, lipgloss.Top, m.viewGeneral())
+	case 1:
+		content = lipgloss.PlaceVertical(contentHeight, lipgloss.Top, m.viewSharing())
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		header,
+		containerRest.Render(lipgloss.JoinVertical(lipgloss.Left, content, actions)),
+	)
+}
+
+func (m AddFolderModel) viewGeneral() string {
+	labels := []string{"Folder Label", "Folder ID", "Folder Path"}
+	rows := make([]string, 0, 3*len(labels))
+	for i, label := range labels {
+		if i > 0 {
+			rows = append(rows, "")
+		}
+		rows = append(rows, label, zone.Mark(m.inputMark(i), m.inputs[i].View()))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+func (m AddFolderModel) viewSharing() string {
+	if len(m.devices) == 0 {
+		return "No other devices to share with"
+	}
+
+	rows := lo.Map(m.devices, func(device DeviceViewModel, i int) string {
+		_, shared := m.shared[device.Config.DeviceID]
+		row := fmt.Sprintf("%s %s", lo.Ternary(shared, "[x]", "[ ]"), device.Config.Name)
+		if m.focus == addFolderInputs+i {
+			row = lipgloss.NewStyle().Reverse(true).Render(row)
+		}
+		return zone.Mark(m.deviceMark(i), row)
+	})
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		append([]string{"Share With Devices", ""}, rows...)...)
+}
+
+func (m AddFolderModel) viewActions() string {
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		zone.Mark(m.zonePrefix+"save", styles.BtnStyleV2.Render("Save")),
+		"  ",
+		zone.Mark(m.zonePrefix+"close", styles.BtnStyleV2.Render("Close")),
+	)
+}
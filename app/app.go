@@ -2,9 +2,12 @@ package app
 
 import (
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
+	"log"
 	"math"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -13,10 +16,11 @@ import (
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/lipgloss/table"
-	"github.com/davecgh/go-spew/spew"
 	"github.com/dustin/go-humanize"
 	zone "github.com/lrstanley/bubblezone"
 	"github.com/pdrolopes/syncthing_TUI/styles"
@@ -28,7 +32,10 @@ import (
 const (
 	DEFAULT_SYNCTHING_URL            = "http://localhost:8384"
 	REFETCH_STATUS_INTERVAL          = 10 * time.Second
+	REFETCH_FOLDER_STATUS_INTERVAL   = 10 * time.Second
+	REFETCH_FOLDER_STATS_INTERVAL    = time.Minute
 	REFETCH_CURRENT_TIME_INTERVAL    = time.Second
+	STALE_UPDATE_THRESHOLD           = 3 * REFETCH_STATUS_INTERVAL
 	PAUSE_ALL_MARK                   = "pause-all"
 	RESUME_ALL_MARK                  = "resume-all"
 	RESCAN_ALL_MARK                  = "rescan-all"
@@ -36,6 +43,9 @@ const (
 	REVERT_LOCAL_CHANGES_MODAL_AREA  = "revert-local-changes-modal"
 	REVERT_LOCAL_CHANGES_CONFIRM_BTN = "confirm-revert-local-changes"
 	REVERT_LOCAL_CHANGES_CANCEL_BTN  = "cancel-revert-local-changes"
+	// below it the cards and modals don't fit, a classic 80x24 terminal is enough
+	MIN_TERMINAL_WIDTH  = 80
+	MIN_TERMINAL_HEIGHT = 20
 )
 
 var VERSION = "unknown"
@@ -48,8 +58,13 @@ type errMsg error
 // TODO when there a no more bytes to be transfered but still have files to be delete. show as 95%
 
 type model struct {
-	dump                           io.Writer
+	dump io.Writer
+	// startup configuration error, it replaces the whole screen
 	err                            error
+	errBanner                      ErrorBanner
+	clipboard                      string
+	loaded                         bool
+	loading                        Loading
 	width                          int
 	height                         int
 	httpData                       HttpData
@@ -57,16 +72,78 @@ type model struct {
 	ongoingUserAction              bool
 	currentTime                    time.Time
 	addDeviceModal                 AddDeviceModel
+	addFolderModal                 AddFolderModel
 	confirmRevertLocalChangesModal ConfirmRevertLocalAdditions
-	putConfig                      PutConfig
+	confirmOverrideChangesModal    ConfirmOverrideChanges
+	confirmBulkFolderAction        ConfirmBulkFolderAction
+	confirmRemoveDevice            ConfirmRemoveDevice
+	confirmRemoveFolder            ConfirmRemoveFolder
+	eventTimeline                  EventTimelineModel
+	encryptionModal                EncryptionPasswordsModel
+	deviceGroupModal               DeviceGroupModel
+	reconnect                      ReconnectAll
+	upgrade                        Upgrade
+	conflictsModal                 ConflictsModel
+	minHomeDiskFreeModal           MinHomeDiskFreeModel
+	folderAdvancedModal            FolderAdvancedModel
+	folderRescanModal              FolderRescanModel
+	folderIgnoresModal             FolderIgnoresModel
+	folderInviteModal              FolderInviteModel
+	logViewer                      LogViewerModel
+	deviceIDQRModal                DeviceIDQRModel
+	pausedFoldersModal             PausedFoldersModel
+	helpModal                      HelpModel
+	systemPathModal                SystemPathModel
+	// folder IDs paused by Pause All, to tell them from the ones paused one by one
+	bulkPausedFolders    []string
+	folderProgress       FolderProgress
+	labelEditor          LabelEditor
+	scanPrompt           ScanPrompt
+	search               Search
+	sortMode             SortMode
+	putConfig            PutConfig
+	folderStatusInterval time.Duration
+	folderStatsInterval  time.Duration
+	viewMode             ViewMode
+	hideTLSWarning       bool
+	problemsOnly         bool
+	rateWindow           time.Duration
+	// filesystem type of the listed folders, empty lists all
+	filesystemFilter string
+	deviceFilter     DeviceFilter
+	settings         Settings
+	settingsErr      error // the settings file couldn't be read, it is left as is
+	trafficHistory   []TrafficSample
+	eventLog         []syncthing.Event[any]
+	transfers        Transfers
+	selection        Selection
+	jump             JumpPrefix
+	follow           FollowActivity
+	// scroll offset of the folder and device columns
+	viewport viewport.Model
+	// consecutive failed event polls, they space out the retries
+	eventsFailures int
+	// when the last event or poll was successfully processed
+	lastUpdate time.Time
+	// optional endpoints that answered 404 on this syncthing instance
+	unavailableEndpoints map[string]struct{}
+	// conflict copy walks per folder id, folders missing were never walked
+	conflictScans map[string]conflictScan
+	// a manual refresh is in flight
+	refreshing bool
+	// the last fetched connections, the rates of a manual refresh start from them
+	connections syncthing.SystemConnection
 
 	thisDeviceStatus ThisDeviceStatus
 	folders          []FolderViewModel
 	devices          []DeviceViewModel
 
 	// Syncthing DATA
+	// last config received, kept to re-derive what depends on this device ID
+	config         syncthing.Config
 	configDefaults syncthing.Defaults
 	pendingDevices map[string]PendingDevice
+	pendingFolders map[string]PendingFolder
 	version        syncthing.SystemVersion
 }
 
@@ -76,6 +153,12 @@ type FolderViewModel struct {
 	ExtraStats    syncthing.FolderStats
 	ScanProgress  syncthing.FolderScanProgressEventData
 	SharedDevices []string
+	// PendingPause holds the paused state requested by the user while syncthing
+	// hasn't confirmed it yet.
+	PendingPause lo.Tuple2[bool, bool]
+	// the folder is on a local filesystem that doesn't keep unix permissions
+	PermissionsUnsupported bool
+	Conflicts              FolderConflicts
 }
 
 func (fvm FolderViewModel) TogglePauseMark() string {
@@ -86,6 +169,14 @@ func (fvm FolderViewModel) RescanMark() string {
 	return fvm.Config.ID + "-rescan"
 }
 
+func (fvm FolderViewModel) RescanPathMark() string {
+	return fvm.Config.ID + "-rescan-path"
+}
+
+func (fvm FolderViewModel) CreateMarkerMark() string {
+	return fvm.Config.ID + "-create-marker"
+}
+
 func (fvm FolderViewModel) HeaderMark() string {
 	return fvm.Config.ID + "-header"
 }
@@ -94,20 +185,214 @@ func (fvm FolderViewModel) RevertLocalAdditionsMark() string {
 	return fvm.Config.ID + "-revert-local-additions"
 }
 
+func (fvm FolderViewModel) OverrideChangesMark() string {
+	return fvm.Config.ID + "-override-changes"
+}
+
+func (fvm FolderViewModel) EncryptionMark() string {
+	return fvm.Config.ID + "-encryption"
+}
+
+func (fvm FolderViewModel) ConflictsMark() string {
+	return fvm.Config.ID + "-conflicts"
+}
+
+func (fvm FolderViewModel) RescanSettingsMark() string {
+	return fvm.Config.ID + "-rescan-settings"
+}
+
+func (fvm FolderViewModel) IgnoresMark() string {
+	return fvm.Config.ID + "-ignores"
+}
+
+func (fvm FolderViewModel) AdvancedMark() string {
+	return fvm.Config.ID + "-advanced"
+}
+
+func (fvm FolderViewModel) InviteMark() string {
+	return fvm.Config.ID + "-invite"
+}
+
+func (fvm FolderViewModel) RemoveFolderMark() string {
+	return fvm.Config.ID + "-remove"
+}
+
+func (fvm FolderViewModel) SharedWithMark() string {
+	return fvm.Config.ID + "-shared-with"
+}
+
+func (fvm FolderViewModel) IgnorePermsMark() string {
+	return fvm.Config.ID + "-ignore-perms"
+}
+
 type DeviceViewModel struct {
-	Config                 syncthing.DeviceConfig
-	ExtraStats             syncthing.DeviceStats
-	Connection             lo.Tuple2[bool, syncthing.Connection]
-	StatusCompletion       map[string]syncthing.StatusCompletion
-	Folders                []lo.Tuple2[string, string]
+	Config           syncthing.DeviceConfig
+	ExtraStats       syncthing.DeviceStats
+	Connection       lo.Tuple2[bool, syncthing.Connection]
+	StatusCompletion map[string]syncthing.StatusCompletion
+	Folders          []lo.Tuple2[string, string]
+	// labels of shared folders without an encryption password for this device
+	FoldersMissingPassword []string
 	InGoingBytesPerSecond  int64
 	OutGoingBytesPerSecond int64
+	SessionBaseline        SessionBaseline
+	ConnectionHistory      ConnectionHistory
+}
+
+// ConnectionHistory accumulates the connect/disconnect transitions observed
+// while the TUI is running, to tell apart stable peers from flaky ones.
+type ConnectionHistory struct {
+	ObservedSince time.Time
+	// connected time of the already closed connections
+	ConnectedFor time.Duration
+	// zero when the device isn't connected
+	ConnectedAt time.Time
+	Disconnects int
+}
+
+func (h ConnectionHistory) Observe(connected bool, at time.Time) ConnectionHistory {
+	if h.ObservedSince.IsZero() {
+		h.ObservedSince = at
+	}
+
+	switch {
+	case connected && h.ConnectedAt.IsZero():
+		h.ConnectedAt = at
+	case !connected && !h.ConnectedAt.IsZero():
+		h.ConnectedFor += at.Sub(h.ConnectedAt)
+		h.ConnectedAt = time.Time{}
+		h.Disconnects++
+	}
+
+	return h
+}
+
+// UptimePercent is the share of the observed time the device was connected.
+func (h ConnectionHistory) UptimePercent(now time.Time) (float64, bool) {
+	observed := now.Sub(h.ObservedSince)
+	if h.ObservedSince.IsZero() || observed <= 0 {
+		return 0, false
+	}
+
+	connected := h.ConnectedFor
+	if !h.ConnectedAt.IsZero() {
+		connected += now.Sub(h.ConnectedAt)
+	}
+
+	return 100 * connected.Seconds() / observed.Seconds(), true
+}
+
+// SessionBaseline holds the connection byte totals of the first sample seen of
+// the current connection, so traffic can be reported per session.
+type SessionBaseline struct {
+	StartedAt     time.Time
+	InBytesTotal  int64
+	OutBytesTotal int64
+}
+
+func updateSessionBaseline(
+	baseline SessionBaseline,
+	connection syncthing.Connection,
+	has bool,
+) SessionBaseline {
+	if !has || !connection.Connected {
+		return SessionBaseline{}
+	}
+
+	if baseline.StartedAt.Equal(connection.StartedAt) {
+		return baseline
+	}
+
+	// the totals of syncthing outlive its connections, so every session counts from
+	// the first sample we see of it, whether the device was connected at startup or
+	// reconnected later
+	return SessionBaseline{
+		StartedAt:     connection.StartedAt,
+		InBytesTotal:  connection.InBytesTotal,
+		OutBytesTotal: connection.OutBytesTotal,
+	}
 }
 
 func (fvm DeviceViewModel) HeaderMark() string {
 	return fvm.Config.DeviceID + "-header"
 }
 
+func (fvm DeviceViewModel) ToggleUntrustedMark() string {
+	return fvm.Config.DeviceID + "-toggle-untrusted"
+}
+
+func (fvm DeviceViewModel) RemoteGUIMark() string {
+	return fvm.Config.DeviceID + "-remote-gui"
+}
+
+func (fvm DeviceViewModel) RemoveMark() string {
+	return fvm.Config.DeviceID + "-remove"
+}
+
+func (fvm DeviceViewModel) FoldersMark() string {
+	return fvm.Config.DeviceID + "-folders"
+}
+
+// RemoteGUIURL points to the web GUI of the device. The address of the current
+// connection is preferred over the static addresses of the config.
+func (fvm DeviceViewModel) RemoteGUIURL() (string, bool) {
+	if fvm.Config.RemoteGUIPort <= 0 {
+		return "", false
+	}
+
+	addresses := fvm.Config.Addresses
+	if fvm.Connection.A && fvm.Connection.B.Connected {
+		addresses = append([]string{fvm.Connection.B.Address}, addresses...)
+	}
+	for _, address := range addresses {
+		host := addressHost(address)
+		if host == "" {
+			continue
+		}
+
+		return (&url.URL{
+			Scheme: "http",
+			Host:   net.JoinHostPort(host, fmt.Sprint(fvm.Config.RemoteGUIPort)),
+		}).String(), true
+	}
+
+	return "", false
+}
+
+// addressHost extracts the host of "ip:port" or "tcp://host:port" addresses.
+// "dynamic" and relay addresses have no usable host.
+func addressHost(address string) string {
+	if strings.Contains(address, "://") {
+		parsed, err := url.Parse(address)
+		if err != nil || parsed.Scheme == "relay" {
+			return ""
+		}
+		return parsed.Hostname()
+	}
+
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return ""
+	}
+
+	return host
+}
+
+// SessionBytes returns the bytes received and sent during the current connection,
+// since it was first seen.
+func (fvm DeviceViewModel) SessionBytes() (int64, int64) {
+	in := fvm.Connection.B.InBytesTotal - fvm.SessionBaseline.InBytesTotal
+	out := fvm.Connection.B.OutBytesTotal - fvm.SessionBaseline.OutBytesTotal
+	if in < 0 {
+		in = 0
+	}
+	if out < 0 {
+		out = 0
+	}
+
+	return in, out
+}
+
 type ThisDeviceStatus struct {
 	ID                     string
 	Name                   string
@@ -118,6 +403,29 @@ type ThisDeviceStatus struct {
 	UpTime                 int64
 	MaxSendKbps            int
 	MaxRecvKbps            int
+	DiscoveryEnabled       bool
+	// discovery method -> error message, empty when reachable
+	Discovery       map[string]string
+	Diagnostics     Diagnostics
+	MinHomeDiskFree syncthing.DiskSpace
+	// zero when the home disk can't be measured
+	HomeDisk HomeDisk
+	// zero means no limit
+	ConnectionLimitEnough int
+	ConnectionLimitMax    int
+	// zero when auto upgrades are disabled
+	AutoUpgradeIntervalH int
+	// discovery, relays and NAT toggles are read from it
+	Options syncthing.Options
+	// web GUI theme, empty means the default one
+	GUITheme   string
+	CPUPercent float64
+	// memory allocated and obtained from the OS by the syncthing process
+	Alloc      int64
+	Sys        int64
+	Goroutines int
+	// from /rest/system/paths, keyed like in the answer
+	Paths map[string]string
 }
 
 type PendingDevice struct {
@@ -145,11 +453,66 @@ func (list PendingDeviceList) Len() int           { return len(list) }
 func (list PendingDeviceList) Swap(i, j int)      { list[i], list[j] = list[j], list[i] }
 func (list PendingDeviceList) Less(i, j int) bool { return list[i].Name < list[j].Name }
 
+// PendingDeviceByRecent sorts the latest connection attempts first.
+type PendingDeviceByRecent []PendingDevice
+
+func (list PendingDeviceByRecent) Len() int           { return len(list) }
+func (list PendingDeviceByRecent) Swap(i, j int)      { list[i], list[j] = list[j], list[i] }
+func (list PendingDeviceByRecent) Less(i, j int) bool { return list[i].At.After(list[j].At) }
+
 type HttpData struct {
 	// TODO think of a better name
 	client http.Client
 	apiKey string
 	url    url.URL
+	// set when a fallback url is configured
+	failover *failoverTransport
+	// certificates aren't verified on https connections
+	skipVerify bool
+	auth       AuthMode
+}
+
+type AuthMode int
+
+const (
+	AuthAPIKey AuthMode = iota
+	AuthBearer
+)
+
+func ParseAuthMode(auth string) (AuthMode, error) {
+	switch auth {
+	case "", "apikey":
+		return AuthAPIKey, nil
+	case "bearer":
+		return AuthBearer, nil
+	}
+
+	return AuthAPIKey, fmt.Errorf("unknown auth %q, expected \"apikey\" or \"bearer\"", auth)
+}
+
+// authorize sets the api key on the request using the configured auth scheme.
+func (h HttpData) authorize(req *http.Request) {
+	switch h.auth {
+	case AuthBearer:
+		req.Header.Set("Authorization", "Bearer "+h.apiKey)
+	case AuthAPIKey:
+		req.Header.Set("X-API-Key", h.apiKey)
+	}
+}
+
+// InsecureTLS reports whether the active connection uses https without verifying certificates.
+func (h HttpData) InsecureTLS() bool {
+	activeURL := h.ActiveURL()
+	return h.skipVerify && activeURL.Scheme == "https"
+}
+
+// ActiveURL is the syncthing url requests are currently sent to.
+func (h HttpData) ActiveURL() url.URL {
+	if h.failover == nil {
+		return h.url
+	}
+
+	return h.failover.Active()
 }
 
 type ConfirmRevertLocalAdditions struct {
@@ -159,65 +522,348 @@ type ConfirmRevertLocalAdditions struct {
 
 var quitKeys = key.NewBinding(
 	key.WithKeys("q", "esc", "ctrl+c"),
-	key.WithHelp("", "press q to quit"),
+	key.WithHelp("q", "quit"),
+)
+
+var reloadConfigKeys = key.NewBinding(
+	key.WithKeys("c"),
+	key.WithHelp("c", "reload config"),
+)
+
+var toggleTrafficViewKeys = key.NewBinding(
+	key.WithKeys("t"),
+	key.WithHelp("t", "toggle traffic view"),
+)
+
+var toggleStatusKeys = key.NewBinding(
+	key.WithKeys("s"),
+	key.WithHelp("s", "collapse/expand status"),
+)
+
+var eventTimelineKeys = key.NewBinding(
+	key.WithKeys("e"),
+	key.WithHelp("e", "events timeline"),
+)
+
+var pendingSortKeys = key.NewBinding(
+	key.WithKeys("n"),
+	key.WithHelp("n", "sort pending devices by name/recent"),
+)
+
+var selectNextKeys = key.NewBinding(
+	key.WithKeys("down", "j"),
+	key.WithHelp("↓/j", "select the next folder or device"),
+)
+
+var selectPreviousKeys = key.NewBinding(
+	key.WithKeys("up", "k"),
+	key.WithHelp("↑/k", "select the previous folder or device"),
+)
+
+var toggleExpandKeys = key.NewBinding(
+	key.WithKeys("enter"),
+	key.WithHelp("enter", "expand/collapse the selected folder or device"),
+)
+
+var switchSectionKeys = key.NewBinding(
+	key.WithKeys("tab"),
+	key.WithHelp("tab", "switch between folders and devices"),
+)
+
+var jumpKeys = key.NewBinding(
+	key.WithKeys("g"),
+	key.WithHelp("g", "jump to name (type letters after g)"),
+)
+
+var openWebGUIKeys = key.NewBinding(
+	key.WithKeys("o"),
+	key.WithHelp("o", "open web GUI"),
+)
+
+var deviceGroupKeys = key.NewBinding(
+	key.WithKeys("l"),
+	key.WithHelp("l", "edit group of the selected device"),
+)
+
+var groupDevicesKeys = key.NewBinding(
+	key.WithKeys("L"),
+	key.WithHelp("L", "group devices"),
+)
+
+var reconnectAllKeys = key.NewBinding(
+	key.WithKeys("R"),
+	key.WithHelp("R", "reconnect all devices"),
+)
+
+var conflictsKeys = key.NewBinding(
+	key.WithKeys("C"),
+	key.WithHelp("C", "conflicts of the selected folder"),
+)
+
+var minHomeDiskFreeKeys = key.NewBinding(
+	key.WithKeys("m"),
+	key.WithHelp("m", "edit minimum home disk free space"),
+)
+
+var folderAdvancedKeys = key.NewBinding(
+	key.WithKeys("A"),
+	key.WithHelp("A", "advanced options of the selected folder"),
+)
+
+var folderInviteKeys = key.NewBinding(
+	key.WithKeys("I"),
+	key.WithHelp("I", "invite a peer to the selected folder"),
+)
+
+var timeStyleKeys = key.NewBinding(
+	key.WithKeys("a"),
+	key.WithHelp("a", "toggle absolute/relative times"),
+)
+
+var sizeStyleKeys = key.NewBinding(
+	key.WithKeys("b"),
+	key.WithHelp("b", "toggle exact byte counts"),
+)
+
+var folderLabelKeys = key.NewBinding(
+	key.WithKeys("E"),
+	key.WithHelp("E", "rename the selected folder"),
+)
+
+var pinKeys = key.NewBinding(
+	key.WithKeys("f"),
+	key.WithHelp("f", "pin/unpin the selected folder or device"),
+)
+
+var upgradeKeys = key.NewBinding(
+	key.WithKeys("U"),
+	key.WithHelp("U", "upgrade syncthing to the latest release"),
+)
+
+var problemsOnlyKeys = key.NewBinding(
+	key.WithKeys("P"),
+	key.WithHelp("P", "show only the folders and devices needing attention"),
+)
+
+var ignorePermsKeys = key.NewBinding(
+	key.WithKeys("p"),
+	key.WithHelp("p", "toggle ignore permissions of the selected folder"),
 )
 
-func NewModel() model {
-	var dump *os.File
-	if _, ok := os.LookupEnv("DEBUG"); ok {
-		var err error
-		dump, err = os.OpenFile("messages.log", os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+// Options are the startup settings coming from the command line.
+type Options struct {
+	View ViewMode
+	// hex color overriding the default accent, empty keeps the default
+	AccentColor string
+	// hides the insecure TLS warning and badge
+	HideTLSWarning bool
+	Auth           AuthMode
+	// take precedence over the SYNCTHING_URL and SYNCTHING_API_KEY envs when set
+	URL    string
+	APIKey string
+	// only render the folders and devices needing attention
+	ProblemsOnly bool
+	// smoothing of the byte rates, zero shows the rate of the last refresh
+	RateWindow time.Duration
+	// replaying a messages log, recording would truncate it
+	Replay bool
+}
+
+func NewModel(options Options) model {
+	// left nil without DEBUG, a nil *os.File in it would pass the m.dump != nil check
+	var dump io.Writer
+	if _, ok := os.LookupEnv("DEBUG"); ok && !options.Replay {
+		// the log holds the full api traffic, keep it to the user
+		file, err := os.OpenFile("messages.log", os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
 		if err != nil {
 			os.Exit(1)
 		}
+		// the mode only applies to new files, a log from an older run may be readable
+		if err := file.Chmod(0o600); err != nil {
+			os.Exit(1)
+		}
+		dump = file
+	}
+	if options.AccentColor != "" && styles.SetAccentColor(options.AccentColor) == nil {
+		setTabHighlight(styles.AccentColor)
+	}
+
+	httpData, err := newHttpData(options)
+	if httpData.InsecureTLS() && !options.HideTLSWarning {
+		log.Printf(
+			"warning: TLS certificate verification is disabled for %s\n",
+			httpData.url.String(),
+		)
+	}
+
+	folderStatusInterval, envErr := durationFromEnv(
+		"SYNCTHING_FOLDER_STATUS_INTERVAL",
+		REFETCH_FOLDER_STATUS_INTERVAL,
+	)
+	if envErr != nil && err == nil {
+		err = envErr
+	}
+	folderStatsInterval, envErr := durationFromEnv(
+		"SYNCTHING_FOLDER_STATS_INTERVAL",
+		REFETCH_FOLDER_STATS_INTERVAL,
+	)
+	if envErr != nil && err == nil {
+		err = envErr
+	}
+
+	settings, settingsErr := loadSettings()
+	m := model{
+		httpData:             httpData,
+		dump:                 dump,
+		err:                  err,
+		expandedFields:       make(map[string]struct{}),
+		unavailableEndpoints: make(map[string]struct{}),
+		conflictScans:        make(map[string]conflictScan),
+		pendingDevices:       make(map[string]PendingDevice),
+		pendingFolders:       make(map[string]PendingFolder),
+		currentTime:          time.Now(),
+		folderStatusInterval: folderStatusInterval,
+		folderStatsInterval:  folderStatsInterval,
+		viewMode:             options.View,
+		hideTLSWarning:       options.HideTLSWarning,
+		problemsOnly:         options.ProblemsOnly,
+		rateWindow:           options.RateWindow,
+		settings:             settings,
+		settingsErr:          settingsErr,
+		search:               NewSearch(),
+	}
+	if settingsErr != nil {
+		m.errBanner = newErrorBanner(settingsErr, m.currentTime)
+	}
+	return m.restoreWorkspace(options)
+}
+
+// ParseSyncthingURL validates a syncthing address given on the command line.
+func ParseSyncthingURL(raw string) (*url.URL, error) {
+	syncthingURL, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url %q: %w", raw, err)
+	}
+	if syncthingURL.Scheme != "http" && syncthingURL.Scheme != "https" {
+		return nil, fmt.Errorf("invalid url %q, expected http:// or https://", raw)
+	}
+	if syncthingURL.Host == "" {
+		return nil, fmt.Errorf("invalid url %q, missing host", raw)
+	}
+
+	return syncthingURL, nil
+}
+
+// newHttpData builds the syncthing client from the url and api key options, falling
+// back to the SYNCTHING_API_KEY, SYNCTHING_URL and SYNCTHING_FALLBACK_URL envs.
+func newHttpData(options Options) (HttpData, error) {
+	syncthingApiKey := options.APIKey
+	if syncthingApiKey == "" {
+		syncthingApiKey = os.Getenv("SYNCTHING_API_KEY")
 	}
-	syncthingApiKey := os.Getenv("SYNCTHING_API_KEY")
 	envUrl, hasEnv := os.LookupEnv("SYNCTHING_URL")
 	if !hasEnv {
 		envUrl = DEFAULT_SYNCTHING_URL
 	}
-	syncthingURL, err := url.Parse(envUrl)
-	if err != nil {
-		err = fmt.Errorf("invalid syncthing host: %w", err)
-	}
 
 	client := http.Client{
 		Transport: &http.Transport{
 			TLSClientConfig: &tls.Config{
 				InsecureSkipVerify: true, // Skip certificate verification
 			},
+			DialContext: (&net.Dialer{Timeout: DIAL_TIMEOUT}).DialContext,
 		},
 	}
 	httpData := HttpData{
-		apiKey: syncthingApiKey,
-		client: client,
-		url:    *syncthingURL,
+		apiKey:     syncthingApiKey,
+		client:     client,
+		skipVerify: true,
+		auth:       options.Auth,
+	}
+
+	var syncthingURL *url.URL
+	var err error
+	if options.URL != "" {
+		syncthingURL, err = ParseSyncthingURL(options.URL)
+	} else {
+		syncthingURL, err = url.Parse(envUrl)
+	}
+	if err != nil {
+		return httpData, fmt.Errorf("invalid syncthing host: %w", err)
+	}
+	httpData.url = *syncthingURL
+
+	if envFallback, hasFallback := os.LookupEnv("SYNCTHING_FALLBACK_URL"); hasFallback {
+		fallbackURL, err := ParseSyncthingURL(envFallback)
+		if err != nil {
+			return httpData, fmt.Errorf("invalid syncthing fallback host: %w", err)
+		}
+		httpData.failover = newFailoverTransport(
+			httpData.client.Transport,
+			[]url.URL{*syncthingURL, *fallbackURL},
+		)
+		httpData.client.Transport = httpData.failover
+	}
+
+	return httpData, nil
+}
+
+func (m model) timeStyle() TimeStyle {
+	return lo.Ternary(m.settings.AbsoluteTimes, TimeAbsolute, TimeRelative)
+}
+
+func (m model) sizeStyle() SizeStyle {
+	return lo.Ternary(m.settings.ExactBytes, SizeExact, SizeHuman)
+}
+
+func (m model) isEndpointAvailable(endpoint string) bool {
+	_, unavailable := m.unavailableEndpoints[endpoint]
+	return !unavailable
+}
+
+func durationFromEnv(name string, fallback time.Duration) (time.Duration, error) {
+	value, has := os.LookupEnv(name)
+	if !has {
+		return fallback, nil
 	}
 
-	return model{
-		httpData:       httpData,
-		dump:           dump,
-		err:            err,
-		expandedFields: make(map[string]struct{}),
-		pendingDevices: make(map[string]PendingDevice),
-		currentTime:    time.Now(),
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return fallback, fmt.Errorf("invalid %s duration: %q", name, value)
 	}
+
+	return duration, nil
 }
 
+// Init only asks for what the loading screen waits on, the dashboard requests
+// start once syncthing answered.
 func (m model) Init() tea.Cmd {
-	return tea.Sequence(
-		tea.SetWindowTitle("tui-syncthing"),
-		fetchSystemStatus(m.httpData),
-		fetchConfig(m.httpData),
-		tea.Batch(
-			fetchSystemConnections(m.httpData, syncthing.SystemConnection{}),
-			fetchSystemVersion(m.httpData),
-			fetchEvents(m.httpData, 0),
-			fetchDeviceStats(m.httpData),
-			fetchFolderStats(m.httpData),
-			fetchPendingDevices(m.httpData),
-			currentTimeCmd(),
-		))
+	return tea.Batch(
+		tea.Sequence(
+			tea.SetWindowTitle("tui-syncthing"),
+			fetchSystemStatus(m.httpData),
+			fetchConfig(m.httpData),
+		),
+		// keeps the retry countdown of the loading screen moving
+		currentTimeCmd(),
+	)
+}
+
+func (m model) fetchDashboard() tea.Cmd {
+	return tea.Batch(
+		fetchSystemConnections(m.httpData, syncthing.SystemConnection{}),
+		fetchSystemVersion(m.httpData),
+		fetchEvents(m.httpData, 0),
+		fetchDeviceStats(m.httpData),
+		fetchFolderStats(m.httpData),
+		fetchPendingDevices(m.httpData),
+		fetchPendingFolders(m.httpData),
+		fetchHomeDisk(m.httpData),
+		fetchSystemPaths(m.httpData),
+		fetchSystemUpgrade(m.httpData),
+		refreshFolderStatusCmd(m.folderStatusInterval),
+		refreshFolderStatsCmd(m.folderStatsInterval),
+	)
 }
 
 // ------------------------------- MSGS ---------------------------------
@@ -231,11 +877,15 @@ type FetchedEventsMsg struct {
 	events []syncthing.Event[any]
 	since  int
 	err    error
+	// events whose data didn't fully decode, they are kept with what could be read
+	decodeErrors []error
 }
 
 type FetchedSystemStatusMsg struct {
 	status syncthing.SystemStatus
 	err    error
+	// asked with r, the periodic fetch is already scheduled
+	manual bool
 }
 
 type FetchedSystemVersionMsg struct {
@@ -247,98 +897,600 @@ type FetchedSystemConnectionsMsg struct {
 	prevConnections syncthing.SystemConnection
 	connections     syncthing.SystemConnection
 	err             error
+	// asked with r, the periodic fetch is already scheduled
+	manual bool
+}
+
+type FetchedConfig struct {
+	config syncthing.Config
+	err    error
 }
 
-type FetchedConfig struct {
-	config syncthing.Config
-	err    error
-}
+type FetchedFolderStats struct {
+	folderStats map[string]syncthing.FolderStats
+	err         error
+}
+
+type FetchedDeviceStats struct {
+	deviceStats map[string]syncthing.DeviceStats
+	err         error
+}
+
+type FetchedCompletion struct {
+	deviceID      string
+	folderID      string
+	completion    syncthing.StatusCompletion
+	hasCompletion bool
+	err           error
+}
+
+type TickedCurrentTimeMsg struct {
+	currentTime time.Time
+}
+
+type TickedFolderStatusRefreshMsg struct{}
+
+type TickedFolderStatsRefreshMsg struct{}
+
+type UserPostPutEndedMsg struct {
+	action   string
+	folderID string
+	err      error
+}
+
+type FetchedPendingDevices struct {
+	err     error
+	devices map[string]syncthing.PendingDeviceInfo
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.dump != nil {
+		_ = recordMsg(m.dump, m.redactMsg(msg))
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.addDeviceModal.Show {
+			var cmd tea.Cmd
+			m.addDeviceModal, cmd = m.addDeviceModal.Update(msg)
+			return m, cmd
+		}
+
+		if m.addFolderModal.Show {
+			var cmd tea.Cmd
+			m.addFolderModal, cmd = m.addFolderModal.Update(msg)
+			return m, cmd
+		}
+
+		if m.confirmRevertLocalChangesModal.Show {
+			return handleKeyBoardEventsRevertModal(m, msg)
+		}
+
+		if m.confirmOverrideChangesModal.Show {
+			return handleKeyBoardEventsOverrideModal(m, msg)
+		}
+
+		if m.confirmBulkFolderAction.Show {
+			return handleKeyBoardEventsBulkFolderModal(m, msg)
+		}
+
+		if m.confirmRemoveDevice.Show {
+			return handleKeyBoardEventsRemoveDeviceModal(m, msg)
+		}
+
+		if m.confirmRemoveFolder.Show {
+			return handleKeyBoardEventsRemoveFolderModal(m, msg)
+		}
+
+		if m.reconnect.ShowConfirm {
+			return handleKeyBoardEventsReconnectModal(m, msg)
+		}
+
+		if m.upgrade.ShowConfirm {
+			return handleKeyBoardEventsUpgradeModal(m, msg)
+		}
+
+		if m.eventTimeline.Show {
+			m.eventTimeline = m.eventTimeline.Update(msg)
+			return m, nil
+		}
+
+		if m.encryptionModal.Show {
+			var cmd tea.Cmd
+			m.encryptionModal, cmd = m.encryptionModal.Update(msg)
+			return m, cmd
+		}
+
+		if m.deviceGroupModal.Show {
+			var cmd tea.Cmd
+			m.deviceGroupModal, cmd = m.deviceGroupModal.Update(msg)
+			return m, cmd
+		}
+
+		if m.conflictsModal.Show {
+			var cmd tea.Cmd
+			m.conflictsModal, cmd = m.conflictsModal.Update(msg)
+			return m, cmd
+		}
+
+		if m.minHomeDiskFreeModal.Show {
+			var cmd tea.Cmd
+			m.minHomeDiskFreeModal, cmd = m.minHomeDiskFreeModal.Update(msg)
+			return m, cmd
+		}
+
+		if m.folderAdvancedModal.Show {
+			var cmd tea.Cmd
+			m.folderAdvancedModal, cmd = m.folderAdvancedModal.Update(msg)
+			return m, cmd
+		}
+
+		if m.folderRescanModal.Show {
+			var cmd tea.Cmd
+			m.folderRescanModal, cmd = m.folderRescanModal.Update(msg)
+			return m, cmd
+		}
+
+		if m.folderIgnoresModal.Show {
+			var cmd tea.Cmd
+			m.folderIgnoresModal, cmd = m.folderIgnoresModal.Update(msg)
+			return m, cmd
+		}
+
+		if m.folderInviteModal.Show {
+			var cmd tea.Cmd
+			m.folderInviteModal, cmd = m.folderInviteModal.Update(msg)
+			return m, cmd
+		}
+
+		if m.logViewer.Show {
+			var cmd tea.Cmd
+			m.logViewer, cmd = m.logViewer.Update(msg)
+			return m, cmd
+		}
+
+		if m.deviceIDQRModal.Show {
+			var cmd tea.Cmd
+			m.deviceIDQRModal, cmd = m.deviceIDQRModal.Update(msg)
+			return m, cmd
+		}
+
+		if m.pausedFoldersModal.Show {
+			var cmd tea.Cmd
+			m.pausedFoldersModal, cmd = m.pausedFoldersModal.Update(msg)
+			return m, cmd
+		}
+
+		if m.helpModal.Show {
+			var cmd tea.Cmd
+			m.helpModal, cmd = m.helpModal.Update(msg)
+			return m, cmd
+		}
+
+		if m.systemPathModal.Show {
+			var cmd tea.Cmd
+			m.systemPathModal, cmd = m.systemPathModal.Update(msg)
+			return m, cmd
+		}
+
+		if m.labelEditor.Active() {
+			var cmd tea.Cmd
+			m.labelEditor, cmd = m.labelEditor.Update(msg, m.httpData)
+			return m, cmd
+		}
+
+		if m.scanPrompt.Active() {
+			var cmd tea.Cmd
+			m.scanPrompt, cmd = m.scanPrompt.Update(msg, m.httpData)
+			return m, cmd
+		}
+
+		if m.search.Editing {
+			var cmd tea.Cmd
+			m.search, cmd = m.search.Update(msg)
+			m.viewport.SetYOffset(0)
+			return m, cmd
+		}
+
+		if m.jump.Active {
+			// esc only leaves the jump, it would quit otherwise
+			if msg.Type == tea.KeyEsc {
+				m.jump = JumpPrefix{}
+				return m, nil
+			}
+			// m.currentTime keeps replays deterministic
+			if msg.Type == tea.KeyRunes && m.currentTime.Sub(m.jump.LastKey) <= JUMP_TIMEOUT {
+				m.jump = m.jump.Type(string(msg.Runes), m.currentTime)
+				if selection, found := jumpTo(
+					m.selection.Section,
+					m.jump.Prefix,
+					m.folders,
+					m.devices,
+				); found {
+					m.selection = selection
+				}
+				return m.revealSelection(), nil
+			}
+			m.jump = JumpPrefix{}
+		}
+
+		switch {
+		case key.Matches(msg, dismissErrorKeys):
+			m.errBanner = ErrorBanner{}
+			return m, nil
+		case msg.Type == tea.KeyEsc && m.search.Active():
+			m.search = m.search.Clear()
+			return m, nil
+		case key.Matches(msg, quitKeys):
+			return m, tea.Quit
+		case key.Matches(msg, helpKeys):
+			m.helpModal = NewHelp(m.width)
+			return m, nil
+		case key.Matches(msg, searchKeys):
+			var cmd tea.Cmd
+			m.search, cmd = m.search.Focus()
+			return m, cmd
+		case key.Matches(msg, reloadConfigKeys):
+			return m, fetchConfig(m.httpData)
+		case key.Matches(msg, openWebGUIKeys):
+			activeURL := m.httpData.ActiveURL()
+			if activeURL.Host == "" {
+				return m, nil
+			}
+			return m, openBrowser(activeURL.String())
+		case key.Matches(msg, deviceGroupKeys):
+			device, found := lo.Find(m.devices, func(d DeviceViewModel) bool {
+				return d.Config.DeviceID == m.selection.DeviceID()
+			})
+			if !found {
+				return m, nil
+			}
+			m.deviceGroupModal = NewDeviceGroup(
+				device,
+				m.settings.DeviceGroups[device.Config.DeviceID],
+			)
+			return m, m.deviceGroupModal.Init()
+		case key.Matches(msg, groupDevicesKeys):
+			m.settings.GroupDevices = !m.settings.GroupDevices
+			return m, m.saveSettings()
+		case key.Matches(msg, reconnectAllKeys):
+			if m.reconnect.Active {
+				return m, nil
+			}
+			m.reconnect.ShowConfirm = true
+			return m, nil
+		case key.Matches(msg, upgradeKeys):
+			m.upgrade.ShowConfirm = m.upgrade.Available()
+			return m, nil
+		case key.Matches(msg, conflictsKeys):
+			folder, found := lo.Find(m.folders, func(f FolderViewModel) bool {
+				return f.Config.ID == m.selection.FolderID()
+			})
+			if !found {
+				return m, nil
+			}
+			m.conflictsModal = NewConflicts(folder)
+			return m, nil
+		case key.Matches(msg, minHomeDiskFreeKeys):
+			if m.putConfig == nil {
+				return m, nil
+			}
+			m.minHomeDiskFreeModal = NewMinHomeDiskFree(
+				m.thisDeviceStatus.MinHomeDiskFree,
+				m.putConfig,
+				m.httpData,
+			)
+			return m, m.minHomeDiskFreeModal.Init()
+		case key.Matches(msg, folderAdvancedKeys):
+			folder, found := lo.Find(m.folders, func(f FolderViewModel) bool {
+				return f.Config.ID == m.selection.FolderID()
+			})
+			if !found {
+				return m, nil
+			}
+			m.folderAdvancedModal = NewFolderAdvanced(folder, m.httpData)
+			return m, m.folderAdvancedModal.Init()
+		case key.Matches(msg, folderRescanKeys):
+			folder, found := lo.Find(m.folders, func(f FolderViewModel) bool {
+				return f.Config.ID == m.selection.FolderID()
+			})
+			if !found {
+				return m, nil
+			}
+			m.folderRescanModal = NewFolderRescan(folder, m.httpData)
+			return m, m.folderRescanModal.Init()
+		case key.Matches(msg, folderIgnoresKeys):
+			folder, found := lo.Find(m.folders, func(f FolderViewModel) bool {
+				return f.Config.ID == m.selection.FolderID()
+			})
+			if !found {
+				return m, nil
+			}
+			m.folderIgnoresModal = NewFolderIgnores(folder, m.httpData)
+			return m, m.folderIgnoresModal.Init()
+		case key.Matches(msg, folderInviteKeys):
+			folder, found := lo.Find(m.folders, func(f FolderViewModel) bool {
+				return f.Config.ID == m.selection.FolderID()
+			})
+			if !found {
+				return m, nil
+			}
+			m.folderInviteModal = NewFolderInvite(folder, m.thisDeviceStatus.ID)
+			return m, nil
+		case key.Matches(msg, sizeStyleKeys):
+			m.settings.ExactBytes = !m.settings.ExactBytes
+			return m, m.saveSettings()
+		case key.Matches(msg, timeStyleKeys):
+			m.settings.AbsoluteTimes = !m.settings.AbsoluteTimes
+			return m, m.saveSettings()
+		case key.Matches(msg, folderLabelKeys):
+			folder, found := lo.Find(m.folders, func(f FolderViewModel) bool {
+				return f.Config.ID == m.selection.FolderID()
+			})
+			if !found {
+				return m, nil
+			}
+			m.labelEditor = NewLabelEditor(folder)
+			return m, m.labelEditor.Init()
+		case key.Matches(msg, pinKeys):
+			if m.selection.ID == "" {
+				return m, nil
+			}
+			switch m.selection.Section {
+			case SectionFolders:
+				m.settings.PinnedFolders = togglePin(m.settings.PinnedFolders, m.selection.ID)
+			case SectionDevices:
+				m.settings.PinnedDevices = togglePin(m.settings.PinnedDevices, m.selection.ID)
+			}
+			return m, m.saveSettings()
+		case key.Matches(msg, pausedFoldersKeys):
+			m.pausedFoldersModal = NewPausedFolders(m.folders, m.bulkPausedFolders)
+			return m, nil
+		case key.Matches(msg, deviceIDQRKeys):
+			if m.thisDeviceStatus.ID == "" {
+				return m, nil
+			}
+			m.deviceIDQRModal = NewDeviceIDQR(m.thisDeviceStatus.ID, m.width, m.height)
+			return m, nil
+		case key.Matches(msg, systemLogKeys):
+			width, height := logViewerSize(m.width, m.height)
+			m.logViewer = NewLogViewer(m.httpData, width, height)
+			return m, m.logViewer.Init()
+		case key.Matches(msg, followActivityKeys):
+			m.settings.FollowActivity = !m.settings.FollowActivity
+			m.follow = FollowActivity{}
+			return m, m.saveSettings()
+		case key.Matches(msg, refreshKeys):
+			if m.refreshing {
+				return m, nil
+			}
+			m.refreshing = true
+			return m, m.refresh()
+		case key.Matches(msg, sortKeys):
+			m.sortMode = m.sortMode.Next()
+			return m.revealSelection().rememberWorkspace()
+		case key.Matches(msg, deviceFilterKeys):
+			m.deviceFilter = m.deviceFilter.Next()
+			return m.rememberWorkspace()
+		case key.Matches(msg, filesystemFilterKeys):
+			m.filesystemFilter = nextFilesystemFilter(m.folders, m.filesystemFilter)
+			return m.rememberWorkspace()
+		case key.Matches(msg, problemsOnlyKeys):
+			m.problemsOnly = !m.problemsOnly
+			return m.rememberWorkspace()
+		case key.Matches(msg, ignorePermsKeys):
+			folder, found := lo.Find(m.folders, func(f FolderViewModel) bool {
+				return f.Config.ID == m.selection.FolderID()
+			})
+			if !found || m.ongoingUserAction {
+				return m, nil
+			}
+			m.ongoingUserAction = true
+			return m, updateFolderIgnorePerms(
+				m.httpData,
+				folder.Config.ID,
+				!folder.Config.IgnorePerms,
+			)
+		case key.Matches(msg, jumpKeys):
+			m.jump = JumpPrefix{Active: true, LastKey: m.currentTime}
+			return m, nil
+		case key.Matches(msg, switchSectionKeys):
+			m.selection = Selection{Section: m.selection.Section.Other()}
+			return m, nil
+		case key.Matches(msg, selectNextKeys):
+			m.selection.ID = moveSelection(m.selectableIDs(), m.selection.ID, 1)
+			return m.revealSelection(), nil
+		case key.Matches(msg, selectPreviousKeys):
+			m.selection.ID = moveSelection(m.selectableIDs(), m.selection.ID, -1)
+			return m.revealSelection(), nil
+		case key.Matches(msg, scrollPageDownKeys):
+			return m.scroll(func(vp *viewport.Model) { vp.PageDown() }), nil
+		case key.Matches(msg, scrollPageUpKeys):
+			return m.scroll(func(vp *viewport.Model) { vp.PageUp() }), nil
+		case key.Matches(msg, scrollDownKeys):
+			return m.scroll(func(vp *viewport.Model) { vp.ScrollDown(1) }), nil
+		case key.Matches(msg, scrollUpKeys):
+			return m.scroll(func(vp *viewport.Model) { vp.ScrollUp(1) }), nil
+		case key.Matches(msg, scrollTopKeys):
+			return m.scroll(func(vp *viewport.Model) { vp.GotoTop() }), nil
+		case key.Matches(msg, scrollBottomKeys):
+			return m.scroll(func(vp *viewport.Model) { vp.GotoBottom() }), nil
+		case key.Matches(msg, toggleExpandKeys):
+			if m.selection.ID != "" {
+				toggleExpanded(m.expandedFields, m.selection.ID)
+			}
+			return m, nil
+		case key.Matches(msg, eventTimelineKeys):
+			m.eventTimeline.Show = true
+			return m, nil
+		case key.Matches(msg, pendingSortKeys):
+			m.settings.PendingSortByName = !m.settings.PendingSortByName
+			return m, m.saveSettings()
+		case key.Matches(msg, toggleStatusKeys):
+			m.settings.StatusCollapsed = !m.settings.StatusCollapsed
+			return m, m.saveSettings()
+		case key.Matches(msg, toggleTrafficViewKeys):
+			m.viewMode = lo.Ternary(m.viewMode == ViewTraffic, ViewDefault, ViewTraffic)
+			return m.rememberWorkspace()
+		default:
+			return m, nil
+		}
+	case tea.MouseMsg:
+		if m.addDeviceModal.Show {
+			var cmd tea.Cmd
+			m.addDeviceModal, cmd = m.addDeviceModal.Update(msg)
+			return m, cmd
+		}
+
+		if m.addFolderModal.Show {
+			var cmd tea.Cmd
+			m.addFolderModal, cmd = m.addFolderModal.Update(msg)
+			return m, cmd
+		}
+		if m.confirmRevertLocalChangesModal.Show {
+			return handleMouseEventsRevertModal(m, msg)
+		}
+		if m.confirmOverrideChangesModal.Show {
+			return handleMouseEventsOverrideModal(m, msg)
+		}
+		if m.confirmBulkFolderAction.Show {
+			return handleMouseEventsBulkFolderModal(m, msg)
+		}
+		if m.confirmRemoveDevice.Show {
+			return handleMouseEventsRemoveDeviceModal(m, msg)
+		}
+		if m.confirmRemoveFolder.Show {
+			return handleMouseEventsRemoveFolderModal(m, msg)
+		}
+		if m.reconnect.ShowConfirm {
+			return handleMouseEventsReconnectModal(m, msg)
+		}
+		if m.upgrade.ShowConfirm {
+			return handleMouseEventsUpgradeModal(m, msg)
+		}
+		if m.eventTimeline.Show {
+			return m, nil
+		}
+		if m.encryptionModal.Show {
+			var cmd tea.Cmd
+			m.encryptionModal, cmd = m.encryptionModal.Update(msg)
+			return m, cmd
+		}
 
-type FetchedFolderStats struct {
-	folderStats map[string]syncthing.FolderStats
-	err         error
-}
+		if m.deviceGroupModal.Show {
+			var cmd tea.Cmd
+			m.deviceGroupModal, cmd = m.deviceGroupModal.Update(msg)
+			return m, cmd
+		}
 
-type FetchedDeviceStats struct {
-	deviceStats map[string]syncthing.DeviceStats
-	err         error
-}
+		if m.conflictsModal.Show {
+			var cmd tea.Cmd
+			m.conflictsModal, cmd = m.conflictsModal.Update(msg)
+			return m, cmd
+		}
 
-type FetchedCompletion struct {
-	deviceID      string
-	folderID      string
-	completion    syncthing.StatusCompletion
-	hasCompletion bool
-	err           error
-}
+		if m.minHomeDiskFreeModal.Show {
+			var cmd tea.Cmd
+			m.minHomeDiskFreeModal, cmd = m.minHomeDiskFreeModal.Update(msg)
+			return m, cmd
+		}
 
-type TickedCurrentTimeMsg struct {
-	currentTime time.Time
-}
+		if m.folderAdvancedModal.Show {
+			var cmd tea.Cmd
+			m.folderAdvancedModal, cmd = m.folderAdvancedModal.Update(msg)
+			return m, cmd
+		}
 
-type UserPostPutEndedMsg struct {
-	action string
-	err    error
-}
+		if m.folderRescanModal.Show {
+			var cmd tea.Cmd
+			m.folderRescanModal, cmd = m.folderRescanModal.Update(msg)
+			return m, cmd
+		}
 
-type FetchedPendingDevices struct {
-	err     error
-	devices map[string]syncthing.PendingDeviceInfo
-}
+		if m.folderIgnoresModal.Show {
+			var cmd tea.Cmd
+			m.folderIgnoresModal, cmd = m.folderIgnoresModal.Update(msg)
+			return m, cmd
+		}
 
-func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	if m.dump != nil {
-		spew.Fdump(m.dump, msg)
-	}
+		if m.folderInviteModal.Show {
+			var cmd tea.Cmd
+			m.folderInviteModal, cmd = m.folderInviteModal.Update(msg)
+			return m, cmd
+		}
 
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		if m.addDeviceModal.Show {
+		if m.logViewer.Show {
 			var cmd tea.Cmd
-			m.addDeviceModal, cmd = m.addDeviceModal.Update(msg)
+			m.logViewer, cmd = m.logViewer.Update(msg)
 			return m, cmd
 		}
 
-		if m.confirmRevertLocalChangesModal.Show {
-			return handleKeyBoardEventsRevertModal(m, msg)
+		if m.deviceIDQRModal.Show {
+			var cmd tea.Cmd
+			m.deviceIDQRModal, cmd = m.deviceIDQRModal.Update(msg)
+			return m, cmd
 		}
 
-		switch {
-		case key.Matches(msg, quitKeys):
-			return m, tea.Quit
-		default:
-			return m, nil
+		if m.pausedFoldersModal.Show {
+			var cmd tea.Cmd
+			m.pausedFoldersModal, cmd = m.pausedFoldersModal.Update(msg)
+			return m, cmd
 		}
-	case tea.MouseMsg:
-		if m.addDeviceModal.Show {
+
+		if m.helpModal.Show {
 			var cmd tea.Cmd
-			m.addDeviceModal, cmd = m.addDeviceModal.Update(msg)
+			m.helpModal, cmd = m.helpModal.Update(msg)
 			return m, cmd
 		}
-		if m.confirmRevertLocalChangesModal.Show {
-			return handleMouseEventsRevertModal(m, msg)
+
+		if m.systemPathModal.Show {
+			var cmd tea.Cmd
+			m.systemPathModal, cmd = m.systemPathModal.Update(msg)
+			return m, cmd
 		}
 
 		if msg.Action == tea.MouseActionRelease && msg.Button == tea.MouseButtonLeft {
 			return handleMouseLeftClick(m, msg)
 		}
+		if msg.Action == tea.MouseActionPress && m.viewMode == ViewDefault {
+			switch msg.Button {
+			case tea.MouseButtonWheelDown:
+				return m.scroll(func(vp *viewport.Model) { vp.ScrollDown(SCROLL_WHEEL_LINES) }), nil
+			case tea.MouseButtonWheelUp:
+				return m.scroll(func(vp *viewport.Model) { vp.ScrollUp(SCROLL_WHEEL_LINES) }), nil
+			}
+		}
 
 		return m, nil
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.addDeviceModal = m.addDeviceModal.Resize(addDeviceModalSize(m.width, m.height))
+		m.addFolderModal = m.addFolderModal.Resize(addDeviceModalSize(m.width, m.height))
+		m.logViewer = m.logViewer.Resize(logViewerSize(m.width, m.height))
+		m.deviceIDQRModal = m.deviceIDQRModal.Resize(m.width, m.height)
+		m.helpModal = m.helpModal.Resize(m.width)
 		return m, nil
 	case FetchedEventsMsg:
 		if msg.err != nil {
-			// TODO figure out what to do if event errors
-			m.err = msg.err
-			return m, wait(time.Second, fetchEvents(m.httpData, msg.since))
+			m.errBanner = newErrorBanner(msg.err, m.currentTime)
+			m.eventsFailures++
+			return m, wait(
+				backoffDelay(m.eventsFailures, EVENTS_RETRY_MIN, EVENTS_RETRY_MAX),
+				fetchEvents(m.httpData, msg.since),
+			)
+		}
+		m.eventsFailures = 0
+		if err := eventsDecodeError(msg.decodeErrors); err != nil {
+			m.errBanner = newErrorBanner(err, m.currentTime)
 		}
 
 		since := 0
 		if len(msg.events) > 0 {
 			since = msg.events[len(msg.events)-1].ID
 		}
+		m.eventLog = appendEventLog(m.eventLog, msg.events)
+		m.lastUpdate = m.currentTime
 
 		// ignore the first request
 		if msg.since == 0 {
@@ -352,8 +1504,16 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.folders = updateFolderStatus(m.folders, lo.T2(data.Folder, data.Summary))
 			case syncthing.Config:
 				m.putConfig = createPutConfig(data)
+				m.config = data
 				m.folders = updateFolderViewModelConfigs(data, m.folders, m.thisDeviceStatus.ID)
 				m.devices = updateDeviceViewModelConfigs(data, m.devices, m.thisDeviceStatus.ID)
+				m.thisDeviceStatus.MinHomeDiskFree = data.Options.MinHomeDiskFree
+				m.thisDeviceStatus.ConnectionLimitEnough = data.Options.ConnectionLimitEnough
+				m.thisDeviceStatus.ConnectionLimitMax = data.Options.ConnectionLimitMax
+				m.thisDeviceStatus.AutoUpgradeIntervalH = data.Options.AutoUpgradeIntervalH
+				m.thisDeviceStatus.Options = data.Options
+				m.thisDeviceStatus.GUITheme = data.GUI.Theme
+				cmds = append(cmds, checkFolderFilesystems(m.httpData, data.Folders))
 			case syncthing.FolderScanProgressEventData:
 				m.folders = updateFolderScan(m.folders, data)
 			case syncthing.StateChangedEventData:
@@ -361,7 +1521,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.folders = updateFolderScan(m.folders, syncthing.FolderScanProgressEventData{})
 				}
 				if data.From == "scanning" && data.To == "idle" {
-					cmds = append(cmds, fetchFolderStats(m.httpData))
+					cmds = append(cmds,
+						fetchFolderStats(m.httpData),
+						m.debounceConflictScan(data.Folder),
+					)
 				}
 			case syncthing.FolderCompletionEventData:
 				updateDeviceStatusCompletion(m.devices, data.Device, data.Folder,
@@ -388,6 +1551,30 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				for _, removed := range data.Removed {
 					delete(m.pendingDevices, removed.DeviceID)
 				}
+			case syncthing.PendingFoldersChangedEventData:
+				for _, added := range data.Added {
+					key := pendingFolderKey(added.FolderID, added.DeviceID)
+					m.pendingFolders[key] = PendingFolder{
+						FolderID:         added.FolderID,
+						Label:            added.FolderLabel,
+						DeviceID:         added.DeviceID,
+						ReceiveEncrypted: added.ReceiveEncrypted,
+						At:               e.Time,
+					}
+				}
+				for _, removed := range data.Removed {
+					delete(m.pendingFolders, pendingFolderKey(removed.FolderID, removed.DeviceID))
+				}
+			case syncthing.DeviceConnectedEventData:
+				m.devices = updateDeviceConnected(m.devices, data.ID, true, e.Time)
+			case syncthing.DeviceDisconnectedEventData:
+				m.devices = updateDeviceConnected(m.devices, data.ID, false, e.Time)
+			case syncthing.FolderWatchStateChangedEventData:
+				m.folders = updateFolderWatchError(m.folders, data.Folder, data.To)
+			case syncthing.ItemStartedEventData:
+				m.transfers = m.transfers.Started(data, e.Time)
+			case syncthing.ItemFinishedEventData:
+				m.transfers = m.transfers.Finished(data, e.Time)
 
 			default:
 			}
@@ -395,70 +1582,170 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, fetchEvents(m.httpData, since))
 		return m, tea.Batch(cmds...)
 	case FetchedSystemStatusMsg:
+		if msg.err != nil && !m.loaded {
+			m.loading = m.loading.failed(msg.err, m.currentTime)
+			return m, wait(m.loading.RetryDelay(), fetchSystemStatus(m.httpData))
+		}
+		if msg.err != nil && msg.manual {
+			m.errBanner = newErrorBanner(msg.err, m.currentTime)
+			return m, nil
+		}
 		if msg.err != nil {
-			// TODO create system status error ux
-			m.err = msg.err
+			m.errBanner = newErrorBanner(msg.err, m.currentTime)
 			return m, wait(REFETCH_STATUS_INTERVAL, fetchSystemStatus(m.httpData))
 		}
+		m.loading.statusLoaded = true
+		var loadedCmd tea.Cmd
+		m, loadedCmd = m.finishLoading()
+		cmds := []tea.Cmd{loadedCmd}
+		if !msg.manual {
+			cmds = append(cmds, wait(REFETCH_STATUS_INTERVAL, fetchSystemStatus(m.httpData)))
+		}
+		// the config may have been processed before knowing which device is this one
+		if m.thisDeviceStatus.ID != msg.status.MyID && m.putConfig != nil {
+			m.folders = updateFolderViewModelConfigs(m.config, m.folders, msg.status.MyID)
+			m.devices = updateDeviceViewModelConfigs(m.config, m.devices, msg.status.MyID)
+			m.thisDeviceStatus.Name = thisDeviceName(msg.status.MyID, m.config)
+		}
 		m.thisDeviceStatus.ID = msg.status.MyID
 		m.thisDeviceStatus.UpTime = msg.status.Uptime
-		return m, wait(REFETCH_STATUS_INTERVAL, fetchSystemStatus(m.httpData))
+		m.thisDeviceStatus.CPUPercent = msg.status.CPUPercent
+		m.thisDeviceStatus.Alloc = msg.status.Alloc
+		m.thisDeviceStatus.Sys = msg.status.Sys
+		m.thisDeviceStatus.Goroutines = msg.status.Goroutines
+		m.thisDeviceStatus.DiscoveryEnabled = msg.status.DiscoveryEnabled
+		m.thisDeviceStatus.Discovery = discoveryResults(msg.status)
+		m.thisDeviceStatus.Diagnostics = systemDiagnostics(msg.status)
+		m.lastUpdate = m.currentTime
+		return m, tea.Batch(cmds...)
+	case CopyToClipboardMsg:
+		m.clipboard = msg.text
+		return m, clipboardWritten(msg.text)
+	case ClipboardWrittenMsg:
+		// a newer copy keeps its own sequence
+		if m.clipboard == msg.text {
+			m.clipboard = ""
+		}
+		return m, nil
+	case RefreshEndedMsg:
+		m.refreshing = false
+		return m, nil
 	case FetchedSystemVersionMsg:
 		if msg.err != nil {
-			// TODO create system status error ux
-			m.err = msg.err
-			return m, nil
+			m.errBanner = newErrorBanner(msg.err, m.currentTime)
+			// fetched once otherwise, try again until it is known
+			return m, wait(REFETCH_STATUS_INTERVAL, fetchSystemVersion(m.httpData))
 		}
 		m.version = msg.version
 		return m, nil
 	case FetchedSystemConnectionsMsg:
-		if msg.err != nil {
-			// TODO create system status error ux
-			m.err = msg.err
+		if msg.err != nil && msg.manual {
+			m.errBanner = newErrorBanner(msg.err, m.currentTime)
 			return m, nil
 		}
+		if msg.err != nil {
+			m.errBanner = newErrorBanner(msg.err, m.currentTime)
+			// the rates of the next poll span the failed one
+			return m, wait(
+				REFETCH_STATUS_INTERVAL,
+				fetchSystemConnections(m.httpData, msg.prevConnections),
+			)
+		}
 
+		m.connections = msg.connections
 		m.thisDeviceStatus.InBytesTotal = msg.connections.Total.InBytesTotal
 		m.thisDeviceStatus.OutBytesTotal = msg.prevConnections.Total.OutBytesTotal
-		m.thisDeviceStatus.InGoingBytesPerSecond, m.thisDeviceStatus.OutGoingBytesPerSecond = calcInOutBytes(
-			msg.prevConnections.Total,
-			msg.connections.Total,
-		)
+		m.lastUpdate = m.currentTime
+		elapsed := msg.connections.Total.At.Sub(msg.prevConnections.Total.At)
+		inRate, outRate := calcInOutBytes(msg.prevConnections.Total, msg.connections.Total)
+		m.thisDeviceStatus.InGoingBytesPerSecond = smoothRate(
+			m.thisDeviceStatus.InGoingBytesPerSecond, inRate, elapsed, m.rateWindow)
+		m.thisDeviceStatus.OutGoingBytesPerSecond = smoothRate(
+			m.thisDeviceStatus.OutGoingBytesPerSecond, outRate, elapsed, m.rateWindow)
+		m.trafficHistory = appendTrafficSample(m.trafficHistory, TrafficSample{
+			InBytesPerSecond:  m.thisDeviceStatus.InGoingBytesPerSecond,
+			OutBytesPerSecond: m.thisDeviceStatus.OutGoingBytesPerSecond,
+		})
 
 		{
 			devices := make([]DeviceViewModel, 0, len(m.devices))
 			for _, device := range m.devices {
-				device.InGoingBytesPerSecond, device.OutGoingBytesPerSecond = calcInOutBytes(
+				inRate, outRate := calcInOutBytes(
 					msg.prevConnections.Connections[device.Config.DeviceID],
 					msg.connections.Connections[device.Config.DeviceID])
+				device.InGoingBytesPerSecond = smoothRate(
+					device.InGoingBytesPerSecond, inRate, elapsed, m.rateWindow)
+				device.OutGoingBytesPerSecond = smoothRate(
+					device.OutGoingBytesPerSecond, outRate, elapsed, m.rateWindow)
 				connection, has := msg.connections.Connections[device.Config.DeviceID]
 				device.Connection = lo.T2(has, connection)
+				device.ConnectionHistory = device.ConnectionHistory.Observe(
+					has && connection.Connected,
+					m.currentTime,
+				)
+				device.SessionBaseline = updateSessionBaseline(
+					device.SessionBaseline,
+					connection,
+					has,
+				)
 				devices = append(devices, device)
 			}
 			m.devices = devices
 		}
 
+		if msg.manual {
+			return m, nil
+		}
 		return m, wait(REFETCH_STATUS_INTERVAL, fetchSystemConnections(m.httpData, msg.connections))
 	case FetchedFolderStats:
+		if errors.Is(msg.err, ErrEndpointUnavailable) {
+			m.unavailableEndpoints[STATS_FOLDER] = struct{}{}
+			return m, nil
+		}
 		if msg.err != nil {
-			// TODO create system status error ux
-			m.err = msg.err
+			// TickedFolderStatsRefreshMsg keeps polling
+			m.errBanner = newErrorBanner(msg.err, m.currentTime)
 			return m, nil
 		}
 
 		m.folders = updateFolderStats(m.folders, msg.folderStats)
+		m.lastUpdate = m.currentTime
 		return m, nil
 	case UserPostPutEndedMsg:
-		m.err = msg.err
+		if msg.err != nil {
+			m.errBanner = newErrorBanner(msg.err, m.currentTime)
+		}
 		m.ongoingUserAction = false
+		if msg.err != nil && msg.folderID != "" {
+			m.folders = clearFolderPendingPause(m.folders, msg.folderID)
+		}
+
+		return m, nil
+	case SavedEncryptionPasswordsMsg:
+		if m.encryptionModal.Show && m.encryptionModal.folderID == msg.folderID {
+			var cmd tea.Cmd
+			m.encryptionModal, cmd = m.encryptionModal.Update(msg)
+			return m, cmd
+		}
+		// the modal was closed before the save ended, it can't show the failure
+		if msg.err != nil {
+			m.errBanner = newErrorBanner(msg.err, m.currentTime)
+		}
 
 		return m, nil
 	case FetchedConfig:
+		if msg.err != nil && !m.loaded {
+			m.loading.LastErr = msg.err
+			return m, wait(m.loading.RetryDelay(), fetchConfig(m.httpData))
+		}
 		if msg.err != nil {
-			m.err = msg.err
+			m.errBanner = newErrorBanner(msg.err, m.currentTime)
 			return m, nil
 		}
-		cmds := make([]tea.Cmd, 0)
+		m.loading.configLoaded = true
+		var loadedCmd tea.Cmd
+		m, loadedCmd = m.finishLoading()
+		cmds := []tea.Cmd{loadedCmd}
 		for _, f := range msg.config.Folders {
 			cmds = append(cmds, fetchFolderStatus(m.httpData, f.ID))
 
@@ -467,12 +1754,25 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		cmds = append(cmds,
+			checkFolderFilesystems(m.httpData, msg.config.Folders),
+			m.scanNewFolderConflicts(msg.config.Folders),
+		)
+
 		m.putConfig = createPutConfig(msg.config)
+		m.config = msg.config
 		m.folders = updateFolderViewModelConfigs(msg.config, m.folders, m.thisDeviceStatus.ID)
 		m.devices = updateDeviceViewModelConfigs(msg.config, m.devices, m.thisDeviceStatus.ID)
 		m.thisDeviceStatus.Name = thisDeviceName(m.thisDeviceStatus.ID, msg.config)
 		m.thisDeviceStatus.MaxSendKbps = msg.config.Options.MaxSendKbps
 		m.thisDeviceStatus.MaxRecvKbps = msg.config.Options.MaxRecvKbps
+		m.thisDeviceStatus.MinHomeDiskFree = msg.config.Options.MinHomeDiskFree
+		m.thisDeviceStatus.ConnectionLimitEnough = msg.config.Options.ConnectionLimitEnough
+		m.thisDeviceStatus.ConnectionLimitMax = msg.config.Options.ConnectionLimitMax
+		m.thisDeviceStatus.AutoUpgradeIntervalH = msg.config.Options.AutoUpgradeIntervalH
+		m.thisDeviceStatus.Options = msg.config.Options
+		m.thisDeviceStatus.GUITheme = msg.config.GUI.Theme
+		m.lastUpdate = m.currentTime
 
 		return m, tea.Batch(cmds...)
 	case FetchedFolderStatus:
@@ -482,19 +1782,140 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		m.folders = updateFolderStatus(m.folders, lo.T2(msg.id, msg.folderStatus))
+		m.lastUpdate = m.currentTime
 		return m, nil
-	case FetchedDeviceStats:
+	case PausedDevicesMsg:
+		if msg.err != nil {
+			m.errBanner = newErrorBanner(msg.err, m.currentTime)
+		}
+		// resume even after a failure, devices must not stay paused
+		return m, wait(RECONNECT_PAUSE, resumeDevices(m.httpData, msg.deviceIDs))
+	case FetchedSystemUpgradeMsg:
+		if errors.Is(msg.err, ErrEndpointUnavailable) {
+			m.unavailableEndpoints[SYSTEM_UPGRADE] = struct{}{}
+			return m, nil
+		}
+		if errors.Is(msg.err, ErrUpgradeUnsupported) {
+			m.upgrade.Unsupported = true
+			return m, nil
+		}
+		if msg.err != nil {
+			// a failed release check keeps the last known release, while upgrading
+			// syncthing is most likely still restarting
+			interval := lo.Ternary(
+				m.upgrade.Upgrading,
+				UPGRADE_RESTART_DELAY,
+				REFETCH_UPGRADE_INTERVAL,
+			)
+			return m, wait(interval, fetchSystemUpgrade(m.httpData))
+		}
+
+		cmds := []tea.Cmd{wait(REFETCH_UPGRADE_INTERVAL, fetchSystemUpgrade(m.httpData))}
+		if m.upgrade.Upgrading {
+			m.upgrade.Upgrading = false
+			cmds = append(cmds, fetchSystemVersion(m.httpData))
+		}
+		m.upgrade.Release = msg.upgrade
+		return m, tea.Batch(cmds...)
+	case UpgradedSystemMsg:
+		if msg.err != nil {
+			m.errBanner = newErrorBanner(msg.err, m.currentTime)
+			m.upgrade.Upgrading = false
+			return m, nil
+		}
+		// syncthing restarts with the new release
+		return m, wait(UPGRADE_RESTART_DELAY, fetchSystemUpgrade(m.httpData))
+	case ResumedDevicesMsg:
+		if msg.err != nil {
+			m.errBanner = newErrorBanner(msg.err, m.currentTime)
+			m.reconnect = ReconnectAll{}
+			return m, nil
+		}
+		m.reconnect.Resumed = true
+		m.reconnect.Started = m.currentTime
+		return m, nil
+	case EditedDeviceGroupMsg:
+		groups := make(map[string]string, len(m.settings.DeviceGroups)+1)
+		for deviceID, group := range m.settings.DeviceGroups {
+			groups[deviceID] = group
+		}
+		if msg.group == "" {
+			delete(groups, msg.deviceID)
+		} else {
+			groups[msg.deviceID] = msg.group
+		}
+		m.settings.DeviceGroups = groups
+		return m, m.saveSettings()
+	case FetchedSystemPathsMsg:
+		if errors.Is(msg.err, ErrEndpointUnavailable) {
+			m.unavailableEndpoints[SYSTEM_PATHS] = struct{}{}
+			return m, nil
+		}
+		if msg.err != nil {
+			m.errBanner = newErrorBanner(msg.err, m.currentTime)
+			return m, nil
+		}
+		m.thisDeviceStatus.Paths = msg.paths
+		return m, nil
+	case FetchedHomeDiskMsg:
+		if errors.Is(msg.err, ErrEndpointUnavailable) {
+			m.unavailableEndpoints[SYSTEM_PATHS] = struct{}{}
+			return m, nil
+		}
 		if msg.err != nil {
-			// TODO create system status error ux
-			m.err = msg.err
+			// free space is informative only, keep showing the minimum without it
+			m.thisDeviceStatus.HomeDisk = HomeDisk{}
+			return m, wait(REFETCH_HOME_DISK_INTERVAL, fetchHomeDisk(m.httpData))
+		}
+		m.thisDeviceStatus.HomeDisk = msg.disk
+		return m, wait(REFETCH_HOME_DISK_INTERVAL, fetchHomeDisk(m.httpData))
+	case ConflictScanDueMsg:
+		if m.conflictScans[msg.folderID].generation != msg.generation {
+			return m, nil
+		}
+		folder, found := lo.Find(m.folders, func(f FolderViewModel) bool {
+			return f.Config.ID == msg.folderID
+		})
+		if !found {
+			return m, nil
+		}
+		return m, m.startConflictScan(folder.Config)
+	case ScannedFolderConflictsMsg:
+		m.folders = lo.Map(m.folders, func(f FolderViewModel, index int) FolderViewModel {
+			if f.Config.ID == msg.folderID {
+				f.Conflicts = msg.conflicts
+			}
+			return f
+		})
+		scan := m.conflictScans[msg.folderID]
+		pending := scan.pending
+		scan.running, scan.pending = false, false
+		m.conflictScans[msg.folderID] = scan
+		if pending {
+			return m, m.debounceConflictScan(msg.folderID)
+		}
+		return m, nil
+	case CheckedFolderFilesystemsMsg:
+		m.folders = lo.Map(m.folders, func(f FolderViewModel, index int) FolderViewModel {
+			_, f.PermissionsUnsupported = msg.withoutPermissions[f.Config.ID]
+			return f
+		})
+		return m, nil
+	case FetchedDeviceStats:
+		if errors.Is(msg.err, ErrEndpointUnavailable) {
+			m.unavailableEndpoints[STATS_DEVICE] = struct{}{}
 			return m, nil
 		}
+		if msg.err != nil {
+			m.errBanner = newErrorBanner(msg.err, m.currentTime)
+			// fetched once otherwise, try again until the stats are known
+			return m, wait(REFETCH_STATUS_INTERVAL, fetchDeviceStats(m.httpData))
+		}
 		m.devices = updateDeviceExtraStats(m.devices, msg.deviceStats)
 		return m, nil
 	case FetchedCompletion:
 		if msg.err != nil {
-			// TODO create system status error ux
-			m.err = msg.err
+			m.errBanner = newErrorBanner(msg.err, m.currentTime)
 			return m, nil
 		}
 
@@ -506,9 +1927,13 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		return m, nil
 	case FetchedPendingDevices:
+		if errors.Is(msg.err, ErrEndpointUnavailable) {
+			m.unavailableEndpoints[CLUSTER_PENDING_DEVICES] = struct{}{}
+			return m, nil
+		}
 		if msg.err != nil {
-			// TODO
-			panic(msg.err)
+			m.errBanner = newErrorBanner(msg.err, m.currentTime)
+			return m, nil
 		}
 
 		for deviceID, info := range msg.devices {
@@ -520,19 +1945,164 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		return m, nil
+	case FetchedPendingFolders:
+		if errors.Is(msg.err, ErrEndpointUnavailable) {
+			m.unavailableEndpoints[CLUSTER_PENDING_FOLDERS] = struct{}{}
+			return m, nil
+		}
+		if msg.err != nil {
+			m.errBanner = newErrorBanner(msg.err, m.currentTime)
+			return m, nil
+		}
+
+		m.pendingFolders = pendingFoldersFromInfo(msg.folders)
+
 		return m, nil
 
+	case FollowActivityDueMsg:
+		if !m.settings.FollowActivity || !m.follow.debouncing {
+			return m, nil
+		}
+		return m.followActivity(), nil
 	case TickedCurrentTimeMsg:
 		m.currentTime = msg.currentTime
-		return m, currentTimeCmd()
+		var followCmd tea.Cmd
+		if m.settings.FollowActivity && m.loaded {
+			m.follow, followCmd = m.follow.Observe(m.folders, m.devices)
+		}
+		if !m.errBanner.Visible(m.currentTime) {
+			m.errBanner = ErrorBanner{}
+		}
+		if m.reconnect.Active && m.reconnect.Done(m.devices, m.currentTime) {
+			m.reconnect = ReconnectAll{}
+		}
+		var progressCmd tea.Cmd
+		m.folderProgress, progressCmd = m.folderProgress.Sync(m.folders, FOLDER_PROGRESS_WIDTH)
+		return m, tea.Batch(currentTimeCmd(), progressCmd, followCmd)
+	case ResumeFoldersMsg:
+		cmds := make([]tea.Cmd, 0, len(msg.folderIDs))
+		for _, folderID := range msg.folderIDs {
+			cmds = append(cmds, updateFolderPause(m.httpData, folderID, false))
+			m.folders = setFolderPendingPause(m.folders, folderID, false)
+		}
+		m.bulkPausedFolders = lo.Without(m.bulkPausedFolders, msg.folderIDs...)
+		m.ongoingUserAction = true
+		return m, tea.Batch(cmds...)
+	case CreatedFolderMarkerMsg:
+		if msg.err != nil {
+			m.errBanner = newErrorBanner(msg.err, m.currentTime)
+			return m, nil
+		}
+		// syncthing picks the folder back up on the next scan
+		return m, postScan(m.httpData, msg.folderID, "")
+	case progress.FrameMsg:
+		var cmd tea.Cmd
+		m.folderProgress, cmd = m.folderProgress.Update(msg)
+		return m, cmd
+	case TickedFolderStatusRefreshMsg:
+		cmds := make([]tea.Cmd, 0, len(m.folders)+1)
+		for _, f := range m.folders {
+			cmds = append(cmds, fetchFolderStatus(m.httpData, f.Config.ID))
+		}
+		cmds = append(cmds, refreshFolderStatusCmd(m.folderStatusInterval))
+		return m, tea.Batch(cmds...)
+	case TickedFolderStatsRefreshMsg:
+		if !m.isEndpointAvailable(STATS_FOLDER) {
+			return m, nil
+		}
+		return m, tea.Batch(
+			fetchFolderStats(m.httpData),
+			refreshFolderStatsCmd(m.folderStatsInterval),
+		)
 	case errMsg:
-		m.err = msg
+		m.errBanner = newErrorBanner(msg, m.currentTime)
 		return m, nil
 	default:
+		cmd := m.updateSubComponents(msg)
+		return m, cmd
+	}
+}
+
+// updateSubComponents forwards messages the main model doesn't handle (cursor blinks,
+// ticks, ...) to the components that are currently active, so their commands aren't lost.
+func (m *model) updateSubComponents(msg tea.Msg) tea.Cmd {
+	cmds := make([]tea.Cmd, 0)
+	if m.addDeviceModal.Show {
 		var cmd tea.Cmd
 		m.addDeviceModal, cmd = m.addDeviceModal.Update(msg)
-		return m, cmd
+		cmds = append(cmds, cmd)
+	}
+	if m.addFolderModal.Show {
+		var cmd tea.Cmd
+		m.addFolderModal, cmd = m.addFolderModal.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	if m.encryptionModal.Show {
+		var cmd tea.Cmd
+		m.encryptionModal, cmd = m.encryptionModal.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	if m.deviceGroupModal.Show {
+		var cmd tea.Cmd
+		m.deviceGroupModal, cmd = m.deviceGroupModal.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	if m.minHomeDiskFreeModal.Show {
+		var cmd tea.Cmd
+		m.minHomeDiskFreeModal, cmd = m.minHomeDiskFreeModal.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	if m.folderAdvancedModal.Show {
+		var cmd tea.Cmd
+		m.folderAdvancedModal, cmd = m.folderAdvancedModal.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	if m.folderRescanModal.Show {
+		var cmd tea.Cmd
+		m.folderRescanModal, cmd = m.folderRescanModal.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	if m.folderIgnoresModal.Show {
+		var cmd tea.Cmd
+		m.folderIgnoresModal, cmd = m.folderIgnoresModal.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	if m.logViewer.Show {
+		var cmd tea.Cmd
+		m.logViewer, cmd = m.logViewer.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	if m.labelEditor.Active() {
+		var cmd tea.Cmd
+		m.labelEditor, cmd = m.labelEditor.Update(msg, m.httpData)
+		cmds = append(cmds, cmd)
+	}
+	if m.scanPrompt.Active() {
+		var cmd tea.Cmd
+		m.scanPrompt, cmd = m.scanPrompt.Update(msg, m.httpData)
+		cmds = append(cmds, cmd)
+	}
+	if m.search.Editing {
+		var cmd tea.Cmd
+		m.search, cmd = m.search.Update(msg)
+		cmds = append(cmds, cmd)
 	}
+
+	return tea.Batch(cmds...)
+}
+
+func updateFolderWatchError(
+	folders []FolderViewModel,
+	folderID string,
+	watchError string,
+) []FolderViewModel {
+	return lo.Map(folders, func(f FolderViewModel, index int) FolderViewModel {
+		if f.Config.ID == folderID {
+			f.Status.WatchError = watchError
+		}
+		return f
+	})
 }
 
 func updateFolderViewModelConfigs(
@@ -567,6 +2137,9 @@ func updateFolderViewModelConfigs(
 			if found {
 				currentFVM.Config = folderConfig
 				currentFVM.SharedDevices = sharedDevices
+				if currentFVM.PendingPause.A && currentFVM.PendingPause.B == folderConfig.Paused {
+					currentFVM.PendingPause = lo.T2(false, false)
+				}
 				return currentFVM
 			} else {
 				return FolderViewModel{Config: folderConfig, SharedDevices: sharedDevices}
@@ -603,22 +2176,42 @@ func updateDeviceViewModelConfigs(
 					)
 
 					if foo {
-						return lo.T2(folderConfig.ID, folderConfig.Label), true
+						return lo.T2(folderConfig.ID, folderConfigName(folderConfig)), true
 					} else {
 						return lo.T2("", ""), false
 					}
 				},
 			)
 
+			foldersMissingPassword := lo.FilterMap(
+				config.Folders,
+				func(folderConfig syncthing.FolderConfig, index int) (string, bool) {
+					folderDevice, shared := lo.Find(
+						folderConfig.Devices,
+						func(item syncthing.FolderDevice) bool {
+							return item.DeviceID == deviceConfig.DeviceID
+						},
+					)
+					return folderConfigName(folderConfig),
+						shared && folderDevice.EncryptionPassword == ""
+				},
+			)
+
 			if found {
+				// a config change is newer than the last connections poll
+				if currentDVM.Config.Paused != deviceConfig.Paused {
+					currentDVM.Connection.B.Paused = deviceConfig.Paused
+				}
 				currentDVM.Config = deviceConfig
 				currentDVM.Folders = folders
+				currentDVM.FoldersMissingPassword = foldersMissingPassword
 				return currentDVM, true
 			} else {
 				return DeviceViewModel{
-					Config:           deviceConfig,
-					Folders:          folders,
-					StatusCompletion: make(map[string]syncthing.StatusCompletion),
+					Config:                 deviceConfig,
+					Folders:                folders,
+					FoldersMissingPassword: foldersMissingPassword,
+					StatusCompletion:       make(map[string]syncthing.StatusCompletion),
 				}, true
 			}
 		},
@@ -627,6 +2220,25 @@ func updateDeviceViewModelConfigs(
 	return newDeviceViewModelList
 }
 
+func updateDeviceConnected(
+	devices []DeviceViewModel,
+	deviceID string,
+	connected bool,
+	at time.Time,
+) []DeviceViewModel {
+	return lo.Map(devices, func(item DeviceViewModel, index int) DeviceViewModel {
+		if item.Config.DeviceID == deviceID {
+			item.ConnectionHistory = item.ConnectionHistory.Observe(connected, at)
+			item.Connection.A = true
+			item.Connection.B.Connected = connected
+			if connected {
+				item.Connection.B.StartedAt = at
+			}
+		}
+		return item
+	})
+}
+
 func updateFolderStatus(
 	folders []FolderViewModel,
 	status lo.Tuple2[string, syncthing.FolderStatus],
@@ -641,6 +2253,28 @@ func updateFolderStatus(
 	})
 }
 
+func setFolderPendingPause(
+	folders []FolderViewModel,
+	folderID string,
+	paused bool,
+) []FolderViewModel {
+	return lo.Map(folders, func(item FolderViewModel, index int) FolderViewModel {
+		if item.Config.ID == folderID && item.Config.Paused != paused {
+			item.PendingPause = lo.T2(true, paused)
+		}
+		return item
+	})
+}
+
+func clearFolderPendingPause(folders []FolderViewModel, folderID string) []FolderViewModel {
+	return lo.Map(folders, func(item FolderViewModel, index int) FolderViewModel {
+		if item.Config.ID == folderID {
+			item.PendingPause = lo.T2(false, false)
+		}
+		return item
+	})
+}
+
 func updateFolderStats(
 	folders []FolderViewModel,
 	statsDict map[string]syncthing.FolderStats,
@@ -704,18 +2338,92 @@ func updateDeviceStatusCompletion(
 }
 
 func handleMouseLeftClick(m model, msg tea.MouseMsg) (model, tea.Cmd) {
+	if zone.Get(ERROR_BANNER_DISMISS_BTN).InBounds(msg) {
+		m.errBanner = ErrorBanner{}
+		return m, nil
+	}
+
+	if zone.Get(ADD_FOLDER_MARK).InBounds(msg) {
+		width, height := addDeviceModalSize(m.width, m.height)
+		m.addFolderModal = NewAddFolder(
+			m.config.Defaults.Folder,
+			m.folders,
+			m.devices,
+			m.thisDeviceStatus.ID,
+			m.httpData,
+			width,
+			height,
+		)
+		return m, m.addFolderModal.Init()
+	}
+
+	if zone.Get(PAUSED_FOLDERS_MARK).InBounds(msg) {
+		m.pausedFoldersModal = NewPausedFolders(m.folders, m.bulkPausedFolders)
+		return m, nil
+	}
+
+	if zone.Get(DEVICE_ID_QR_BTN).InBounds(msg) && m.thisDeviceStatus.ID != "" {
+		m.deviceIDQRModal = NewDeviceIDQR(m.thisDeviceStatus.ID, m.width, m.height)
+		return m, nil
+	}
+
+	for _, p := range systemPaths {
+		if zone.Get(SYSTEM_PATH_MARK + p.key).InBounds(msg) {
+			m.systemPathModal = NewSystemPath(
+				m.thisDeviceStatus.Paths,
+				SYSTEM_PATH_MARK+p.key,
+				m.width,
+			)
+			return m, nil
+		}
+	}
+
+	for _, toggle := range networkToggles {
+		if zone.Get(toggle.Mark()).InBounds(msg) {
+			return m.toggleNetworkOption(toggle)
+		}
+	}
+
+	if zone.Get(GUI_THEME_MARK).InBounds(msg) {
+		return m.cycleGUITheme()
+	}
+
+	if zone.Get(UPGRADE_BTN).InBounds(msg) {
+		m.upgrade.ShowConfirm = m.upgrade.Available()
+		return m, nil
+	}
+
+	if zone.Get(REVERT_ALL_MARK).InBounds(msg) {
+		m.confirmBulkFolderAction = newConfirmBulkFolderAction(BulkRevert, m.folders)
+		return m, nil
+	}
+
+	if zone.Get(OVERRIDE_ALL_MARK).InBounds(msg) {
+		m.confirmBulkFolderAction = newConfirmBulkFolderAction(BulkOverride, m.folders)
+		return m, nil
+	}
+
+	// bulk actions only touch the listed folders, respecting the filters
 	if zone.Get(RESCAN_ALL_MARK).InBounds(msg) {
 		cmds := make([]tea.Cmd, 0, len(m.folders))
-		for _, f := range m.folders {
-			cmds = append(cmds, postScan(m.httpData, f.Config.ID))
+		for _, f := range m.visibleFolders() {
+			// a scan already running would only queue another one
+			if folderStatus(f) == Scanning {
+				continue
+			}
+			cmds = append(cmds, postScan(m.httpData, f.Config.ID, ""))
 		}
 		return m, tea.Batch(cmds...)
 	}
 
 	if zone.Get(PAUSE_ALL_MARK).InBounds(msg) && !m.ongoingUserAction {
 		cmds := make([]tea.Cmd, 0, len(m.folders))
-		for _, f := range m.folders {
+		for _, f := range m.visibleFolders() {
+			if !f.Config.Paused {
+				m.bulkPausedFolders = lo.Union(m.bulkPausedFolders, []string{f.Config.ID})
+			}
 			cmds = append(cmds, updateFolderPause(m.httpData, f.Config.ID, true))
+			m.folders = setFolderPendingPause(m.folders, f.Config.ID, true)
 		}
 		m.ongoingUserAction = true
 		return m, tea.Batch(cmds...)
@@ -723,8 +2431,10 @@ func handleMouseLeftClick(m model, msg tea.MouseMsg) (model, tea.Cmd) {
 
 	if zone.Get(RESUME_ALL_MARK).InBounds(msg) {
 		cmds := make([]tea.Cmd, 0, len(m.folders))
-		for _, f := range m.folders {
+		for _, f := range m.visibleFolders() {
 			cmds = append(cmds, updateFolderPause(m.httpData, f.Config.ID, false))
+			m.folders = setFolderPendingPause(m.folders, f.Config.ID, false)
+			m.bulkPausedFolders = lo.Without(m.bulkPausedFolders, f.Config.ID)
 		}
 		m.ongoingUserAction = true
 		return m, tea.Batch(cmds...)
@@ -732,21 +2442,87 @@ func handleMouseLeftClick(m model, msg tea.MouseMsg) (model, tea.Cmd) {
 
 	for _, folder := range m.folders {
 		if zone.Get(folder.HeaderMark()).InBounds(msg) {
-			if _, exists := m.expandedFields[folder.Config.ID]; exists {
-				delete(m.expandedFields, folder.Config.ID)
-			} else {
-				m.expandedFields[folder.Config.ID] = struct{}{}
-			}
+			toggleExpanded(m.expandedFields, folder.Config.ID)
+			return m, nil
+		}
+
+		if zone.Get(folder.SharedWithMark()).InBounds(msg) {
+			toggleExpanded(m.expandedFields, folder.SharedWithMark())
 			return m, nil
 		}
 
 		if zone.Get(folder.TogglePauseMark()).InBounds(msg) && !m.ongoingUserAction {
 			m.ongoingUserAction = true
+			m.bulkPausedFolders = lo.Without(m.bulkPausedFolders, folder.Config.ID)
+			m.folders = setFolderPendingPause(m.folders, folder.Config.ID, !folder.Config.Paused)
 			return m, updateFolderPause(m.httpData, folder.Config.ID, !folder.Config.Paused)
 		}
 
-		if zone.Get(folder.RescanMark()).InBounds(msg) {
-			return m, postScan(m.httpData, folder.Config.ID)
+		if zone.Get(folder.RescanMark()).InBounds(msg) && folderStatus(folder) != Scanning {
+			return m, postScan(m.httpData, folder.Config.ID, "")
+		}
+
+		if zone.Get(folder.RescanPathMark()).InBounds(msg) && folderStatus(folder) != Scanning {
+			m.scanPrompt = NewScanPrompt(folder)
+			return m, m.scanPrompt.Init()
+		}
+
+		if zone.Get(folder.CreateMarkerMark()).InBounds(msg) {
+			return m, createFolderMarker(m.httpData, folder)
+		}
+
+		if zone.Get(folder.EncryptionMark()).InBounds(msg) {
+			m.encryptionModal = NewEncryptionPasswords(
+				folder,
+				m.devices,
+				m.thisDeviceStatus.ID,
+				m.httpData,
+			)
+			return m, m.encryptionModal.Init()
+		}
+
+		if zone.Get(folder.RescanSettingsMark()).InBounds(msg) {
+			m.folderRescanModal = NewFolderRescan(folder, m.httpData)
+			return m, m.folderRescanModal.Init()
+		}
+
+		if zone.Get(folder.IgnoresMark()).InBounds(msg) {
+			m.folderIgnoresModal = NewFolderIgnores(folder, m.httpData)
+			return m, m.folderIgnoresModal.Init()
+		}
+
+		if zone.Get(folder.AdvancedMark()).InBounds(msg) {
+			m.folderAdvancedModal = NewFolderAdvanced(folder, m.httpData)
+			return m, m.folderAdvancedModal.Init()
+		}
+
+		if zone.Get(folder.ConflictsMark()).InBounds(msg) {
+			m.conflictsModal = NewConflicts(folder)
+			return m, nil
+		}
+
+		if zone.Get(folder.RemoveFolderMark()).InBounds(msg) {
+			m.confirmRemoveFolder = ConfirmRemoveFolder{
+				Show:     true,
+				folderID: folder.Config.ID,
+				label:    folderName(folder),
+				path:     folder.Config.Path,
+			}
+			return m, nil
+		}
+
+		if zone.Get(folder.InviteMark()).InBounds(msg) {
+			m.folderInviteModal = NewFolderInvite(folder, m.thisDeviceStatus.ID)
+			return m, nil
+		}
+
+		if zone.Get(folder.IgnorePermsMark()).InBounds(msg) && !m.ongoingUserAction {
+			m.ongoingUserAction = true
+			return m, updateFolderIgnorePerms(
+				m.httpData,
+				folder.Config.ID,
+				!folder.Config.IgnorePerms,
+			)
 		}
 
 		if zone.Get(folder.RevertLocalAdditionsMark()).InBounds(msg) {
@@ -754,17 +2530,46 @@ func handleMouseLeftClick(m model, msg tea.MouseMsg) (model, tea.Cmd) {
 			m.confirmRevertLocalChangesModal.folderID = folder.Config.ID
 			return m, nil
 		}
+
+		if zone.Get(folder.OverrideChangesMark()).InBounds(msg) && overridable(folder) {
+			m.confirmOverrideChangesModal = ConfirmOverrideChanges{
+				Show:     true,
+				folderID: folder.Config.ID,
+			}
+			return m, nil
+		}
 	}
 
 	for _, device := range m.devices {
 		if zone.Get(device.HeaderMark()).InBounds(msg) {
-			if _, exists := m.expandedFields[device.Config.DeviceID]; exists {
-				delete(m.expandedFields, device.Config.DeviceID)
-			} else {
-				m.expandedFields[device.Config.DeviceID] = struct{}{}
+			toggleExpanded(m.expandedFields, device.Config.DeviceID)
+			return m, nil
+		}
+
+		if zone.Get(device.FoldersMark()).InBounds(msg) {
+			toggleExpanded(m.expandedFields, device.FoldersMark())
+			return m, nil
+		}
+
+		if zone.Get(device.RemoteGUIMark()).InBounds(msg) {
+			if remoteURL, ok := device.RemoteGUIURL(); ok {
+				return m, openBrowser(remoteURL)
 			}
+		}
+
+		if zone.Get(device.RemoveMark()).InBounds(msg) {
+			m.confirmRemoveDevice = newConfirmRemoveDevice(device, m.devices)
 			return m, nil
 		}
+
+		if zone.Get(device.ToggleUntrustedMark()).InBounds(msg) && !m.ongoingUserAction {
+			m.ongoingUserAction = true
+			return m, updateDeviceUntrusted(
+				m.httpData,
+				device.Config.DeviceID,
+				!device.Config.Untrusted,
+			)
+		}
 	}
 	for _, pendingDevice := range m.pendingDevices {
 		if zone.Get(pendingDevice.DismissMark()).InBounds(msg) {
@@ -789,16 +2594,52 @@ func handleMouseLeftClick(m model, msg tea.MouseMsg) (model, tea.Cmd) {
 		}
 
 		if zone.Get(pendingDevice.AddMark()).InBounds(msg) {
+			width, height := addDeviceModalSize(m.width, m.height)
 			m.addDeviceModal = NewPendingDevice(
 				m.pendingDevices[pendingDevice.DeviceID].Name,
 				pendingDevice.DeviceID,
 				m.configDefaults.Device,
-				m.httpData)
+				m.folders,
+				m.httpData,
+				m.putConfig,
+				width,
+				height,
+			)
 			cmd := m.addDeviceModal.Init()
 
 			return m, cmd
 		}
 	}
+	for _, pendingFolder := range m.pendingFolders {
+		if zone.Get(pendingFolder.DismissMark()).InBounds(msg) {
+			return m, deletePendingFolder(
+				m.httpData,
+				pendingFolder.FolderID,
+				pendingFolder.DeviceID,
+			)
+		}
+
+		if zone.Get(pendingFolder.IgnoreMark()).InBounds(msg) {
+			cmd := m.putConfig(m.httpData, func(oldConfig syncthing.Config) syncthing.Config {
+				return ignorePendingFolder(oldConfig, pendingFolder, m.currentTime)
+			})
+			return m, cmd
+		}
+
+		if zone.Get(pendingFolder.AddMark()).InBounds(msg) {
+			width, height := addDeviceModalSize(m.width, m.height)
+			m.addFolderModal = NewAddFolder(
+				m.config.Defaults.Folder,
+				m.folders,
+				m.devices,
+				m.thisDeviceStatus.ID,
+				m.httpData,
+				width,
+				height,
+			).FromOffer(pendingFolder.FolderID, pendingFolder.Label, pendingFolder.DeviceID)
+			return m, m.addFolderModal.Init()
+		}
+	}
 
 	return m, nil
 }
@@ -806,38 +2647,231 @@ func handleMouseLeftClick(m model, msg tea.MouseMsg) (model, tea.Cmd) {
 // ------------------ VIEW --------------------------
 
 func (m model) View() string {
+	return viewClipboard(m.clipboard) + m.view()
+}
+
+func (m model) view() string {
 	if m.httpData.apiKey == "" {
-		return "Missing api key to acess syncthing. Env: SYNCTHING_API_KEY"
+		return "Missing api key to acess syncthing. Env: SYNCTHING_API_KEY or flag: -api-key"
+	}
+
+	if !m.loaded {
+		return viewLoading(m.loading, m.httpData, m.currentTime, m.width, m.height)
+	}
+
+	if m.err != nil {
+		return m.err.Error()
+	}
+
+	// zero until the first WindowSizeMsg
+	if m.width > 0 && (m.width < MIN_TERMINAL_WIDTH || m.height < MIN_TERMINAL_HEIGHT) {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+			lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(fmt.Sprintf(
+				"Terminal too small (need at least %dx%d)",
+				MIN_TERMINAL_WIDTH,
+				MIN_TERMINAL_HEIGHT,
+			)))
+	}
+
+	var main string
+	switch m.viewMode {
+	case ViewTraffic:
+		main = lipgloss.NewStyle().MaxHeight(m.height).Render(lipgloss.JoinVertical(
+			lipgloss.Center,
+			lo.Compact([]string{
+				viewErrorBanner(m.errBanner, m.currentTime, m.width),
+				viewTraffic(m.thisDeviceStatus, m.devices, m.trafficHistory, m.width),
+			})...,
+		))
+	case ViewDefault:
+		header, columns := m.viewDashboardHeader(), m.viewDashboardColumns()
+		vp := m.dashboardViewport(header, columns)
+		main = vp.View()
+		if header != "" {
+			main = lipgloss.NewStyle().MaxHeight(m.height).Render(lipgloss.JoinVertical(
+				lipgloss.Left,
+				lipgloss.PlaceHorizontal(lipgloss.Width(columns), lipgloss.Center, header),
+				main,
+			))
+		}
+	}
+
+	if m.addFolderModal.Show {
+		modal := m.addFolderModal.View()
+
+		x := max(0, m.width/2-lipgloss.Width(modal)/2)
+		y := max(0, m.height/2-lipgloss.Height(modal)/2)
+		return zone.Scan(PlaceOverlay(x, y, modal, main, false))
+	}
+
+	if m.addDeviceModal.Show {
+		modal := m.addDeviceModal.View()
+
+		x := max(0, m.width/2-lipgloss.Width(modal)/2)
+		y := max(0, m.height/2-lipgloss.Height(modal)/2)
+		// TODO verify how to remove double zone.Scan
+		return zone.Scan(PlaceOverlay(x, y, modal, main, false))
+	}
+
+	if m.encryptionModal.Show {
+		modal := m.encryptionModal.View()
+
+		x := lipgloss.Width(main)/2 - lipgloss.Width(modal)/2
+		y := 10
+		return zone.Scan(PlaceOverlay(x, y, modal, main, false))
+	}
+
+	if m.folderIgnoresModal.Show {
+		modal := m.folderIgnoresModal.View()
+
+		x := lipgloss.Width(main)/2 - lipgloss.Width(modal)/2
+		y := 10
+		return zone.Scan(PlaceOverlay(x, y, modal, main, false))
+	}
+
+	if m.folderRescanModal.Show {
+		modal := m.folderRescanModal.View()
+
+		x := lipgloss.Width(main)/2 - lipgloss.Width(modal)/2
+		y := 10
+		return zone.Scan(PlaceOverlay(x, y, modal, main, false))
+	}
+
+	if m.folderAdvancedModal.Show {
+		modal := m.folderAdvancedModal.View()
+
+		x := lipgloss.Width(main)/2 - lipgloss.Width(modal)/2
+		y := 10
+		return zone.Scan(PlaceOverlay(x, y, modal, main, false))
+	}
+
+	if m.logViewer.Show {
+		modal := m.logViewer.View()
+
+		x := max(0, m.width/2-lipgloss.Width(modal)/2)
+		y := max(0, m.height/2-lipgloss.Height(modal)/2)
+		return zone.Scan(PlaceOverlay(x, y, modal, main, false))
+	}
+
+	if m.pausedFoldersModal.Show {
+		modal := m.pausedFoldersModal.View()
+
+		x := lipgloss.Width(main)/2 - lipgloss.Width(modal)/2
+		y := 10
+		return zone.Scan(PlaceOverlay(x, y, modal, main, false))
+	}
+
+	if m.systemPathModal.Show {
+		modal := m.systemPathModal.View()
+
+		x := max(0, m.width/2-lipgloss.Width(modal)/2)
+		y := max(0, m.height/2-lipgloss.Height(modal)/2)
+		return zone.Scan(PlaceOverlay(x, y, modal, main, false))
+	}
+
+	if m.helpModal.Show {
+		modal := m.helpModal.View()
+
+		x := max(0, m.width/2-lipgloss.Width(modal)/2)
+		y := max(0, m.height/2-lipgloss.Height(modal)/2)
+		return zone.Scan(PlaceOverlay(x, y, modal, main, false))
+	}
+
+	if m.deviceIDQRModal.Show {
+		modal := m.deviceIDQRModal.View()
+
+		x := max(0, m.width/2-lipgloss.Width(modal)/2)
+		y := max(0, m.height/2-lipgloss.Height(modal)/2)
+		return zone.Scan(PlaceOverlay(x, y, modal, main, false))
+	}
+
+	if m.folderInviteModal.Show {
+		modal := m.folderInviteModal.View()
+
+		x := lipgloss.Width(main)/2 - lipgloss.Width(modal)/2
+		y := 10
+		return zone.Scan(PlaceOverlay(x, y, modal, main, false))
+	}
+
+	if m.minHomeDiskFreeModal.Show {
+		modal := m.minHomeDiskFreeModal.View()
+
+		x := lipgloss.Width(main)/2 - lipgloss.Width(modal)/2
+		y := 10
+		return zone.Scan(PlaceOverlay(x, y, modal, main, false))
+	}
+
+	if m.conflictsModal.Show {
+		modal := m.conflictsModal.View(m.currentTime, m.timeStyle())
+
+		x := lipgloss.Width(main)/2 - lipgloss.Width(modal)/2
+		y := 5
+		return zone.Scan(PlaceOverlay(x, y, modal, main, false))
+	}
+
+	if m.deviceGroupModal.Show {
+		modal := m.deviceGroupModal.View()
+
+		x := lipgloss.Width(main)/2 - lipgloss.Width(modal)/2
+		y := 10
+		return zone.Scan(PlaceOverlay(x, y, modal, main, false))
+	}
+
+	if m.eventTimeline.Show {
+		modal := m.eventTimeline.View(m.eventLog, m.currentTime)
+
+		x := lipgloss.Width(main)/2 - lipgloss.Width(modal)/2
+		y := 2
+		return zone.Scan(PlaceOverlay(x, y, modal, main, false))
+	}
+
+	if m.upgrade.ShowConfirm {
+		modal := viewConfirmUpgrade(m.upgrade)
+
+		x := lipgloss.Width(main)/2 - lipgloss.Width(modal)/2
+		y := 10
+		return zone.Scan(PlaceOverlay(x, y, modal, main, false))
+	}
+
+	if m.reconnect.ShowConfirm {
+		modal := viewConfirmReconnectAll(lo.CountBy(m.devices, func(d DeviceViewModel) bool {
+			return !d.Config.Paused
+		}))
+
+		x := lipgloss.Width(main)/2 - lipgloss.Width(modal)/2
+		y := 10
+		return zone.Scan(PlaceOverlay(x, y, modal, main, false))
+	}
+
+	if m.confirmRemoveFolder.Show {
+		modal := viewConfirmRemoveFolder(m.confirmRemoveFolder)
+
+		x := lipgloss.Width(main)/2 - lipgloss.Width(modal)/2
+		y := 10
+		return zone.Scan(PlaceOverlay(x, y, modal, main, false))
+	}
+
+	if m.confirmRemoveDevice.Show {
+		modal := viewConfirmRemoveDevice(m.confirmRemoveDevice)
+
+		x := lipgloss.Width(main)/2 - lipgloss.Width(modal)/2
+		y := 10
+		return zone.Scan(PlaceOverlay(x, y, modal, main, false))
 	}
 
-	if m.err != nil {
-		return m.err.Error()
-	}
+	if m.confirmBulkFolderAction.Show {
+		modal := viewConfirmBulkFolderAction(m.confirmBulkFolderAction)
 
-	pendingDevices := lo.Values(m.pendingDevices)
-	sort.Sort(PendingDeviceList(pendingDevices))
-
-	main := lipgloss.NewStyle().MaxHeight(m.height).Render(
-		lipgloss.JoinVertical(lipgloss.Center,
-			viewPendingDevices(pendingDevices),
-			lipgloss.JoinHorizontal(lipgloss.Top,
-				viewFolders(m.folders, m.expandedFields),
-				lipgloss.JoinVertical(lipgloss.Left,
-					viewStatus(
-						m.thisDeviceStatus,
-						m.folders,
-						m.version,
-					),
-
-					viewDevices(m.devices, m.currentTime, m.expandedFields),
-				))))
+		x := lipgloss.Width(main)/2 - lipgloss.Width(modal)/2
+		y := 10
+		return zone.Scan(PlaceOverlay(x, y, modal, main, false))
+	}
 
-	if m.addDeviceModal.Show {
-		modal := m.addDeviceModal.View()
+	if m.confirmOverrideChangesModal.Show {
+		modal := viewConfirmOverrideChangesFolder()
 
 		x := lipgloss.Width(main)/2 - lipgloss.Width(modal)/2
 		y := 10
-		// TODO verify how to remove double zone.Scan
 		return zone.Scan(PlaceOverlay(x, y, modal, main, false))
 	}
 
@@ -853,6 +2887,120 @@ func (m model) View() string {
 	return zone.Scan(main)
 }
 
+// viewDashboardHeader renders the panels fixed above the scrolled columns.
+func (m model) viewDashboardHeader() string {
+	pendingDevices := lo.Values(m.pendingDevices)
+	if !m.isEndpointAvailable(CLUSTER_PENDING_DEVICES) {
+		pendingDevices = nil
+	}
+	if m.settings.PendingSortByName {
+		sort.Sort(PendingDeviceList(pendingDevices))
+	} else {
+		sort.Sort(PendingDeviceByRecent(pendingDevices))
+	}
+	pendingFolders := lo.Values(m.pendingFolders)
+	if !m.isEndpointAvailable(CLUSTER_PENDING_FOLDERS) {
+		pendingFolders = nil
+	}
+	sort.Sort(PendingFolderByRecent(pendingFolders))
+
+	var healthyHidden string
+	if m.problemsOnly {
+		folders, devices := m.visibleFolders(), m.visibleDevices()
+		filteredFolders := searchFolders(
+			filterFilesystemType(m.folders, m.filesystemFilter),
+			m.search.Query(),
+		)
+		filteredDevices := searchDevices(
+			filterDevices(m.devices, m.deviceFilter, m.currentTime),
+			m.search.Query(),
+		)
+		hidden := len(filteredFolders) - len(folders) + len(filteredDevices) - len(devices)
+		healthyHidden = viewHealthyHidden(
+			hidden,
+			len(folders)+len(devices)+len(pendingDevices)+len(pendingFolders),
+			m.thisDeviceStatus,
+		)
+	}
+
+	views := lo.Compact([]string{
+		viewErrorBanner(m.errBanner, m.currentTime, m.width),
+		viewPendingDevices(pendingDevices, m.currentTime, m.timeStyle()),
+		viewPendingFolders(pendingFolders, m.devices, m.currentTime, m.timeStyle()),
+		healthyHidden,
+		viewSearch(
+			m.search,
+			len(m.folders)-len(searchFolders(m.folders, m.search.Query()))+
+				len(m.devices)-len(searchDevices(m.devices, m.search.Query())),
+		),
+		viewFilesystemFilter(
+			m.filesystemFilter,
+			len(m.folders)-len(filterFilesystemType(m.folders, m.filesystemFilter)),
+		),
+		viewSortMode(m.sortMode),
+		viewRefreshing(m.refreshing),
+		viewDeviceFilter(
+			m.deviceFilter,
+			len(m.devices)-len(filterDevices(m.devices, m.deviceFilter, m.currentTime)),
+		),
+	})
+	if len(views) == 0 {
+		return ""
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Center, views...)
+}
+
+// viewDashboardColumns renders the folders and the status and devices columns.
+func (m model) viewDashboardColumns() string {
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		viewFolders(
+			m.visibleFolders(),
+			m.currentTime,
+			m.timeStyle(),
+			m.sizeStyle(),
+			m.expandedFields,
+			m.isEndpointAvailable(STATS_FOLDER),
+			m.selection.FolderID(),
+			m.labelEditor,
+			m.scanPrompt,
+			m.settings.PinnedFolders,
+			m.folderProgress,
+		),
+		lipgloss.JoinVertical(lipgloss.Left,
+			viewStatus(
+				m.thisDeviceStatus,
+				m.folders,
+				m.devices,
+				m.version,
+				m.settings.StatusCollapsed,
+				m.httpData,
+				!m.hideTLSWarning,
+				m.lastUpdate,
+				m.currentTime,
+				m.timeStyle(),
+				m.sizeStyle(),
+				m.reconnect,
+				m.upgrade,
+			),
+			viewDiscovery(m.thisDeviceStatus),
+			viewTransfers(m.transfers, m.folders, m.currentTime, m.timeStyle()),
+
+			viewDevices(
+				m.visibleDevices(),
+				m.currentTime,
+				m.timeStyle(),
+				m.sizeStyle(),
+				m.expandedFields,
+				m.isEndpointAvailable(STATS_DEVICE),
+				m.selection.DeviceID(),
+				lo.Ternary(m.settings.GroupDevices, m.settings.DeviceGroups, nil),
+				m.settings.GroupDevices,
+				m.settings.PinnedDevices,
+			),
+		))
+}
+
 func viewConfirmRevertLocalChangesFolder() string {
 	width := 60 // TODO VERIFY MODAL WIDTH
 	header := lipgloss.NewStyle().
@@ -864,7 +3012,7 @@ func viewConfirmRevertLocalChangesFolder() string {
 
 The folder content on this device will be overwritten to become identical with other devices. Files newly added here will be deleted.
 
-Are you sure you want to revert all local changes?
+Are you sure you want to revert all local changes? (y/n)
 `)
 	var actions string
 	{
@@ -876,11 +3024,11 @@ Are you sure you want to revert all local changes?
 		btnCancel := zone.Mark(REVERT_LOCAL_CHANGES_CANCEL_BTN, styles.BtnStyleV2.Render("Cancel"))
 		gap := strings.Repeat(
 			" ",
-			layout.GetWidth()-layout.GetHorizontalPadding()-lipgloss.Width(
+			max(0, layout.GetWidth()-layout.GetHorizontalPadding()-lipgloss.Width(
 				btnConfirm,
 			)-lipgloss.Width(
 				btnCancel,
-			),
+			)),
 		)
 		actions = layout.Render(lipgloss.JoinHorizontal(lipgloss.Top, btnConfirm, gap, btnCancel))
 	}
@@ -905,11 +3053,7 @@ func handleMouseEventsRevertModal(m model, msg tea.MouseMsg) (model, tea.Cmd) {
 	}
 
 	if zone.Get(REVERT_LOCAL_CHANGES_CONFIRM_BTN).InBounds(msg) {
-		folderID := m.confirmRevertLocalChangesModal.folderID
-		m.confirmRevertLocalChangesModal.folderID = ""
-		m.confirmRevertLocalChangesModal.Show = false
-		cmd := postRevertChanges(m.httpData, folderID)
-		return m, cmd
+		return confirmRevertModal(m)
 	}
 
 	if zone.Get(REVERT_LOCAL_CHANGES_CANCEL_BTN).InBounds(msg) {
@@ -921,20 +3065,34 @@ func handleMouseEventsRevertModal(m model, msg tea.MouseMsg) (model, tea.Cmd) {
 	return m, nil
 }
 
+// handleKeyBoardEventsRevertModal swallows every other key, so y and enter can't
+// trigger an action on the dashboard while the modal is open.
 func handleKeyBoardEventsRevertModal(m model, msg tea.KeyMsg) (model, tea.Cmd) {
-	if msg.Type == tea.KeyEscape {
+	switch msg.String() {
+	case "y", "enter":
+		return confirmRevertModal(m)
+	case "esc", "n":
 		m.confirmRevertLocalChangesModal.Show = false
 		m.confirmRevertLocalChangesModal.folderID = ""
-	}
-
-	if msg.String() == "q" || msg.Type == tea.KeyCtrlC || msg.Type == tea.KeyCtrlD {
+	case "q", "ctrl+c", "ctrl+d":
 		return m, tea.Quit
 	}
 
 	return m, nil
 }
 
-func viewPendingDevices(pendingDevices []PendingDevice) string {
+func confirmRevertModal(m model) (model, tea.Cmd) {
+	folderID := m.confirmRevertLocalChangesModal.folderID
+	m.confirmRevertLocalChangesModal.folderID = ""
+	m.confirmRevertLocalChangesModal.Show = false
+	return m, postRevertChanges(m.httpData, folderID)
+}
+
+func viewPendingDevices(
+	pendingDevices []PendingDevice,
+	currentTime time.Time,
+	timeStyle TimeStyle,
+) string {
 	if len(pendingDevices) == 0 {
 		return ""
 	}
@@ -959,7 +3117,7 @@ func viewPendingDevices(pendingDevices []PendingDevice) string {
 		header := headerStyle.Render(
 			spaceAroundTable().Width(width-headerStyle.GetHorizontalPadding()).Row(
 				"New Device",
-				p.At.Format(time.DateTime),
+				FormatInstant(p.At, currentTime, timeStyle),
 			).Render(),
 		)
 
@@ -989,10 +3147,98 @@ func viewPendingDevices(pendingDevices []PendingDevice) string {
 	return lipgloss.JoinVertical(lipgloss.Left, views...)
 }
 
+func connectedDevices(devices []DeviceViewModel) int {
+	return lo.CountBy(devices, func(device DeviceViewModel) bool {
+		return device.Connection.B.Connected
+	})
+}
+
+// connectionLimitLabel shows the active connections against the limits. Past
+// "enough" syncthing stops dialing, at "max" it refuses new connections.
+func connectionLimitLabel(active, enough, limit int) string {
+	if enough <= 0 && limit <= 0 {
+		return fmt.Sprintf("%d active, no limit", active)
+	}
+
+	limits := make([]string, 0, 2)
+	if enough > 0 {
+		limits = append(limits, fmt.Sprintf("enough %d", enough))
+	}
+	if limit > 0 {
+		limits = append(limits, fmt.Sprintf("max %d", limit))
+	}
+	label := fmt.Sprintf("%d active (%s)", active, strings.Join(limits, ", "))
+
+	if limit > 0 && active >= limit {
+		return lipgloss.NewStyle().Foreground(styles.WarningColor).Render("⚠ " + label)
+	}
+
+	return label
+}
+
+// discoveryResults merges the newer discoveryStatus with the legacy discoveryErrors field.
+func discoveryResults(status syncthing.SystemStatus) map[string]string {
+	results := make(map[string]string, len(status.DiscoveryStatus))
+	for method, s := range status.DiscoveryStatus {
+		if s.Error != nil {
+			results[method] = *s.Error
+		} else {
+			results[method] = ""
+		}
+	}
+	for method, e := range status.DiscoveryErrors {
+		results[method] = e
+	}
+
+	return results
+}
+
+func viewDiscovery(this ThisDeviceStatus) string {
+	if !this.DiscoveryEnabled || len(this.Discovery) == 0 {
+		return ""
+	}
+
+	container := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		PaddingRight(1).
+		PaddingLeft(1).
+		Width(50)
+	innerWidth := container.GetWidth() - container.GetHorizontalPadding()
+	okStyle := lipgloss.NewStyle().Foreground(styles.SuccessColor)
+	errorStyle := lipgloss.NewStyle().Foreground(styles.ErrorColor)
+
+	methods := lo.Keys(this.Discovery)
+	sort.Strings(methods)
+	lines := make([]string, 0, len(methods))
+	for _, method := range methods {
+		line := lipgloss.NewStyle().Width(innerWidth).MaxHeight(2)
+		if e := this.Discovery[method]; e != "" {
+			lines = append(lines, line.Render(errorStyle.Render("✗ ")+method+": "+e))
+		} else {
+			lines = append(lines, line.Render(okStyle.Render("✓ ")+method))
+		}
+	}
+
+	header := lipgloss.NewStyle().Bold(true).Render("Discovery")
+	return container.Render(
+		lipgloss.JoinVertical(lipgloss.Left, append([]string{header}, lines...)...),
+	)
+}
+
 func viewStatus(
 	this ThisDeviceStatus,
 	folders []FolderViewModel,
+	devices []DeviceViewModel,
 	version syncthing.SystemVersion,
+	collapsed bool,
+	httpData HttpData,
+	showTLSWarning bool,
+	lastUpdate time.Time,
+	currentTime time.Time,
+	timeStyle TimeStyle,
+	sizeStyle SizeStyle,
+	reconnect ReconnectAll,
+	upgrade Upgrade,
 ) string {
 	foo := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
@@ -1000,6 +3246,22 @@ func viewStatus(
 		PaddingLeft(1).
 		Width(50)
 
+	if collapsed {
+		problems := len(problemFolders(folders))
+		health := lipgloss.NewStyle().Foreground(styles.SuccessColor).Render("✓")
+		if problems > 0 {
+			health = lipgloss.NewStyle().
+				Foreground(styles.ErrorColor).
+				Render(fmt.Sprintf("⚠ %d", problems))
+		}
+		return foo.Render(fmt.Sprintf("%s ↓ %s/s ↑ %s/s %s",
+			lipgloss.NewStyle().Bold(true).Render(this.Name),
+			humanBytes(this.InGoingBytesPerSecond),
+			humanBytes(this.OutGoingBytesPerSecond),
+			health,
+		))
+	}
+
 	var totalFiles, totalDirectories, totalBytes int64
 	for _, f := range folders {
 		totalFiles += int64(f.Status.LocalFiles)
@@ -1012,8 +3274,8 @@ func viewStatus(
 		Row(
 			"Download rate",
 			fmt.Sprintf("%s/s (%s)",
-				humanize.IBytes(uint64(this.InGoingBytesPerSecond)),
-				humanize.IBytes(uint64(this.InBytesTotal)),
+				humanBytes(this.InGoingBytesPerSecond),
+				FormatSize(this.InBytesTotal, sizeStyle),
 			),
 		)
 
@@ -1025,8 +3287,8 @@ func viewStatus(
 
 	t = t.Row("Upload rate",
 		fmt.Sprintf("%s/s (%s)",
-			humanize.IBytes(uint64(this.OutGoingBytesPerSecond)),
-			humanize.IBytes(uint64(this.OutBytesTotal)),
+			humanBytes(this.OutGoingBytesPerSecond),
+			FormatSize(this.OutBytesTotal, sizeStyle),
 		),
 	)
 
@@ -1041,37 +3303,143 @@ func viewStatus(
 		fmt.Sprintf("📄 %d 📁 %d 📁 %s",
 			totalFiles,
 			totalDirectories,
-			humanize.IBytes(uint64(totalBytes))),
+			FormatSize(totalBytes, sizeStyle)),
 	).
-		Row("Uptime", HumanizeDuration(this.UpTime)).
-		Row("Syncthing Version", fmt.Sprintf("%s, %s (%s)", version.Version, osName(version.OS), archName(version.Arch))).
-		Row("Version", VERSION)
+		Row("Uptime", FormatSince(
+			currentTime.Add(-time.Duration(this.UpTime)*time.Second),
+			currentTime,
+			timeStyle,
+		)).
+		Row("Resource Usage", fmt.Sprintf("CPU %.1f%%, RAM %s of %s",
+			this.CPUPercent,
+			humanize.IBytes(uint64(max(this.Alloc, 0))),
+			humanize.IBytes(uint64(max(this.Sys, 0))),
+		)).
+		Row("Devices", fmt.Sprintf("%d/%d connected", connectedDevices(devices), len(devices)))
+	if this.ID != "" {
+		t = t.Row("Device ID", shortIdentification(this.ID)+" "+
+			zone.Mark(DEVICE_ID_QR_BTN, styles.BtnStyleV2.Render("QR")))
+	}
+	if reconnect.Active {
+		t = t.Row("Reconnecting", reconnect.Label(devices))
+	}
+	t = t.Row("Connections", connectionLimitLabel(
+		connectedDevices(devices),
+		this.ConnectionLimitEnough,
+		this.ConnectionLimitMax,
+	))
+	if this.MinHomeDiskFree.Unit != "" {
+		t = t.Row("Min Home Disk Free", homeDiskLabel(this.MinHomeDiskFree, this.HomeDisk))
+	}
+	if this.DiscoveryEnabled && len(this.Discovery) > 0 {
+		reachable := lo.CountBy(lo.Values(this.Discovery), func(e string) bool { return e == "" })
+		summary := fmt.Sprintf("%d/%d", reachable, len(this.Discovery))
+		if reachable < len(this.Discovery) {
+			summary = lipgloss.NewStyle().Foreground(styles.WarningColor).Render("⚠ " + summary)
+		}
+		t = t.Row("Discovery", summary)
+	}
+	t = viewNetworkToggles(t, this.Options)
+	t = t.Row("Web GUI Theme", viewGUITheme(this.GUITheme))
+	if version.Version != "" {
+		t = t.Row("Syncthing Version",
+			fmt.Sprintf("%s, %s (%s)", version.Version, osName(version.OS), archName(version.Arch)))
+	}
+	t = t.Row("Auto Upgrade", autoUpgradeLabel(this.AutoUpgradeIntervalH, upgrade))
+	if upgrade.Upgrading || upgrade.Available() {
+		t = t.Row("Upgrade", viewUpgradeRow(upgrade))
+	}
+	t = t.Row("Version", VERSION)
+	t = t.Row("Updated", viewLastUpdate(lastUpdate, currentTime))
+	if httpData.failover != nil {
+		activeURL := httpData.ActiveURL()
+		endpoint := activeURL.Host
+		if activeURL != httpData.url {
+			endpoint = lipgloss.NewStyle().
+				Foreground(styles.WarningColor).
+				Render(endpoint + " (fallback)")
+		}
+		t = t.Row("Endpoint", endpoint)
+	}
 
 	header := lipgloss.NewStyle().PaddingBottom(1).Bold(true).Render(this.Name)
+	if showTLSWarning && httpData.InsecureTLS() {
+		header = lipgloss.JoinHorizontal(lipgloss.Top,
+			header,
+			" ",
+			lipgloss.NewStyle().Foreground(styles.WarningColor).Render("⚠ insecure TLS"),
+		)
+	}
 	return foo.Render(
-		lipgloss.JoinVertical(
-			lipgloss.Left,
+		lipgloss.JoinVertical(lipgloss.Left, lo.Compact([]string{
 			header,
 			t.Render(),
-		),
+			viewDiagnostics(this.Diagnostics, foo.GetWidth()-foo.GetHorizontalPadding()),
+			viewSystemPaths(this.Paths, foo.GetWidth()-foo.GetHorizontalPadding()),
+		})...),
 	)
 }
 
+// viewLastUpdate shows how fresh the data is, highlighting it once it looks stale.
+func viewLastUpdate(lastUpdate, currentTime time.Time) string {
+	if lastUpdate.IsZero() {
+		return "never"
+	}
+
+	elapsed := max(int64(currentTime.Sub(lastUpdate).Seconds()), 0)
+	label := TimeAgo(lastUpdate, currentTime)
+	if elapsed < 60 {
+		label = fmt.Sprintf("%ds ago", elapsed)
+	}
+	if currentTime.Sub(lastUpdate) > STALE_UPDATE_THRESHOLD {
+		return lipgloss.NewStyle().Foreground(styles.WarningColor).Render("⚠ " + label)
+	}
+
+	return label
+}
+
 func viewFolders(
 	folders []FolderViewModel,
+	currentTime time.Time,
+	timeStyle TimeStyle,
+	sizeStyle SizeStyle,
 	expandedFolder map[string]struct{},
+	hasStats bool,
+	selectedID string,
+	labelEditor LabelEditor,
+	scanPrompt ScanPrompt,
+	pinned []string,
+	folderProgress FolderProgress,
 ) string {
 	views := lo.Map(folders, func(item FolderViewModel, index int) string {
 		_, isExpanded := expandedFolder[item.Config.ID]
-		return viewFolder(item, isExpanded)
+		_, allShares := expandedFolder[item.SharedWithMark()]
+		selected := item.Config.ID == selectedID
+		editor := lo.Ternary(labelEditor.FolderID == item.Config.ID, labelEditor.View(), "")
+		prompt := lo.Ternary(scanPrompt.FolderID == item.Config.ID, scanPrompt.View(), "")
+		isPinned := lo.Contains(pinned, item.Config.ID)
+		return viewFolder(
+			item,
+			currentTime,
+			timeStyle,
+			sizeStyle,
+			isExpanded,
+			allShares,
+			hasStats,
+			selected,
+			editor,
+			prompt,
+			isPinned,
+			folderProgress.View(item.Config.ID),
+		)
 	})
 
-	btns := make([]string, 0)
+	btns := viewBulkFolderBtns(folders)
 	areAllFoldersPaused := lo.EveryBy(
 		folders,
 		func(item FolderViewModel) bool { return item.Config.Paused },
 	)
-	anyFolderPaused := lo.SomeBy(
+	pausedFolders := lo.CountBy(
 		folders,
 		func(item FolderViewModel) bool { return item.Config.Paused },
 	)
@@ -1079,10 +3447,22 @@ func viewFolders(
 	if !areAllFoldersPaused {
 		btns = append(btns, zone.Mark(PAUSE_ALL_MARK, styles.BtnStyleV2.Render("Pause All")))
 	}
-	if anyFolderPaused {
+	if pausedFolders > 0 {
 		btns = append(btns, zone.Mark(RESUME_ALL_MARK, styles.BtnStyleV2.Render("Resume All")))
 	}
-	btns = append(btns, zone.Mark(RESCAN_ALL_MARK, styles.BtnStyleV2.Render("Rescan All")))
+	if pausedFolders > 1 {
+		btns = append(btns, zone.Mark(PAUSED_FOLDERS_MARK,
+			styles.BtnStyleV2.Render(fmt.Sprintf("Resume Some (%d)", pausedFolders))))
+	}
+	allFoldersScanning := len(folders) > 0 && lo.EveryBy(
+		folders,
+		func(item FolderViewModel) bool { return folderStatus(item) == Scanning },
+	)
+	if allFoldersScanning {
+		btns = append(btns, styles.BtnStyleV2.Faint(true).Render("Scanning…"))
+	} else {
+		btns = append(btns, zone.Mark(RESCAN_ALL_MARK, styles.BtnStyleV2.Render("Rescan All")))
+	}
 	btns = append(btns, zone.Mark(ADD_FOLDER_MARK, styles.BtnStyleV2.Render("Add Folder")))
 
 	views = append(views, (lipgloss.JoinHorizontal(lipgloss.Top, btns...)))
@@ -1090,6 +3470,25 @@ func viewFolders(
 	return lipgloss.JoinVertical(lipgloss.Right, views...)
 }
 
+// viewList joins the names on one line of the given display width, replacing the
+// ones that don't fit with "+N more". With all set the names that don't fit on a line
+// are listed one per line.
+func viewList(names []string, width int, all bool) string {
+	line := strings.Join(names, ", ")
+	if all && lipgloss.Width(line) > width {
+		return strings.Join(names, "\n")
+	}
+
+	for shown := len(names) - 1; shown >= 0 && lipgloss.Width(line) > width; shown-- {
+		line = fmt.Sprintf("+%d more", len(names)-shown)
+		if shown > 0 {
+			line = strings.Join(names[:shown], ", ") + ", " + line
+		}
+	}
+
+	return line
+}
+
 func spaceAroundTable() *table.Table {
 	return table.New().
 		BorderTop(false).
@@ -1107,29 +3506,44 @@ func spaceAroundTable() *table.Table {
 
 func viewFolder(
 	folder FolderViewModel,
+	currentTime time.Time,
+	timeStyle TimeStyle,
+	sizeStyle SizeStyle,
 	expanded bool,
+	// list every device the folder is shared with instead of fitting them on a line
+	allShares bool,
+	hasStats bool,
+	selected bool,
+	// rendered label input replacing the label, empty when not renaming
+	labelEditor string,
+	// rendered sub path input under the details, empty when not asking
+	scanPrompt string,
+	pinned bool,
+	// animated progress of a syncing or scanning folder, empty otherwise
+	progressBar string,
 ) string {
-	status := folderStatus(folder)
-	folderStyle := lipgloss.NewStyle().
+	status := folderStatus(optimisticFolder(folder))
+	folderStyle := selectedBorder(lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder(), true).
 		PaddingLeft(1).
 		PaddingRight(1).
 		BorderForeground(folderColor(status)).
-		Width(60)
+		Width(60), selected)
 	folderStyleInnerWidth := folderStyle.GetWidth() - folderStyle.GetHorizontalPadding()
 	boldStyle := lipgloss.NewStyle().Bold(true)
 	var label string
-	if folder.Status.NeedBytes > 0 && status == Syncing {
-		syncPercent := float64(
-			folder.Status.GlobalBytes-folder.Status.NeedBytes,
-		) / float64(
-			folder.Status.GlobalBytes,
-		) * 100
+	if (folder.Status.NeedBytes > 0 || folder.Status.NeedTotalItems > 0) && status == Syncing {
+		var remaining string
+		if folder.Status.GlobalBytes > 0 {
+			remaining = humanBytes(folder.Status.NeedBytes)
+		} else {
+			remaining = fmt.Sprintf("%d items", folder.Status.NeedTotalItems)
+		}
 		label = fmt.Sprintf(
 			"%s (%.0f%%, %s)",
 			folderStatusLabel(status),
-			syncPercent,
-			humanize.IBytes(uint64(folder.Status.NeedBytes)))
+			folderSyncPercent(folder.Status),
+			remaining)
 	} else if status == Scanning && folder.ScanProgress.Total > 0 {
 		scanPercent := float64(folder.ScanProgress.Current) / float64(folder.ScanProgress.Total) * 100
 		label = fmt.Sprintf(
@@ -1140,16 +3554,31 @@ func viewFolder(
 	} else {
 		label = folderStatusLabel(status)
 	}
+	if folder.PendingPause.A {
+		label = lo.Ternary(folder.PendingPause.B, "Pausing…", "Resuming…")
+	}
+	name := boldStyle.Render(pinLabel(folderName(folder), pinned))
+	if labelEditor != "" {
+		name = labelEditor
+	}
+	if folder.Conflicts.Count > 0 {
+		name += lipgloss.NewStyle().
+			Foreground(styles.WarningColor).
+			Render(fmt.Sprintf(" ⚠ %d", folder.Conflicts.Count))
+	}
 	header := spaceAroundTable().
 		Width(folderStyleInnerWidth).
 		Row(
-			boldStyle.Render(folder.Config.Label),
+			name,
 			lipgloss.NewStyle().Foreground(folderColor(status)).Bold(true).Render(label),
 		)
 
 	verticalViews := make([]string, 0)
 	verticalViews = append(verticalViews, zone.Mark(folder.HeaderMark(), header.Render()))
 	if expanded {
+		if progressBar != "" {
+			verticalViews = append(verticalViews, progressBar)
+		}
 		foo := lo.Ternary(folder.Config.FsWatcherEnabled, "Enabled", "Disabled")
 
 		var folderType string
@@ -1173,15 +3602,18 @@ func viewFolder(
 				fmt.Sprintf("📄 %d 📁 %d 📁 %s",
 					folder.Status.GlobalFiles,
 					folder.Status.GlobalDirectories,
-					humanize.IBytes(uint64(folder.Status.GlobalBytes))),
+					FormatSize(folder.Status.GlobalBytes, sizeStyle)),
 			),
 			lo.T2("Local State",
 				fmt.Sprintf("📄 %d 📁 %d 📁 %s",
 					folder.Status.LocalFiles,
 					folder.Status.LocalDirectories,
-					humanize.IBytes(uint64(folder.Status.LocalBytes))),
+					FormatSize(folder.Status.LocalBytes, sizeStyle)),
 			),
 		}
+		if behind := viewFolderBehind(folder.Status, sizeStyle); behind != "" {
+			topRows = append(topRows, lo.T2("Behind by", behind))
+		}
 
 		var middleRows []RowTuple
 		switch status {
@@ -1191,7 +3623,7 @@ func viewFolder(
 				fmt.Sprintf(
 					"%d items, %s",
 					folder.Status.NeedFiles,
-					humanize.IBytes(uint64(folder.Status.NeedBytes)),
+					FormatSize(folder.Status.NeedBytes, sizeStyle),
 				),
 			)}
 		case LocalAdditions, LocalUnencrypted:
@@ -1199,7 +3631,7 @@ func viewFolder(
 				"Locally Changed Items",
 				fmt.Sprintf("%d items, %s",
 					folder.Status.ReceiveOnlyChangedFiles,
-					humanize.IBytes(uint64(folder.Status.ReceiveOnlyChangedBytes))),
+					FormatSize(folder.Status.ReceiveOnlyChangedBytes, sizeStyle)),
 			)}
 		case Scanning:
 			if folder.ScanProgress.Rate > 0 {
@@ -1210,7 +3642,13 @@ func viewFolder(
 					ScanDuration(secondsETA),
 				)}
 			}
-		case Idle, FailedItems, Error, Paused, Unknown, Unshared:
+		case Error:
+			cause := folderFatalCause(folder)
+			middleRows = []RowTuple{
+				lo.T2("Error", lipgloss.NewStyle().Foreground(styles.ErrorColor).Render(cause)),
+				lo.T2("Recovery", folderFatalHint(classifyFolderFatal(cause))),
+			}
+		case Idle, FailedItems, Paused, Unknown, Unshared:
 
 		}
 
@@ -1218,13 +3656,44 @@ func viewFolder(
 			lo.T2("Folder Type", folderType),
 			lo.T2(
 				"Rescans ",
-				fmt.Sprintf("%s  %s", HumanizeDuration(int64(folder.Config.RescanIntervalS)), foo),
+				zone.Mark(folder.RescanSettingsMark(), fmt.Sprintf("%s  %s ✎",
+					HumanizeDuration(int64(folder.Config.RescanIntervalS)), foo)),
 			),
 			lo.T2("File Pull Order", fmt.Sprint(folder.Config.Order)),
 			lo.T2("File Versioning", fmt.Sprint(folder.Config.Versioning.Type)),
-			lo.T2("Shared With", strings.Join(folder.SharedDevices, ", ")),
-			lo.T2("Last Scan", fmt.Sprint(folder.ExtraStats.LastScan.Format(time.DateTime))),
-			lo.T2("Last File", fmt.Sprint(folder.ExtraStats.LastFile.Filename)),
+			// the label column is as wide as the longest label of the card
+			lo.T2("Shared With", zone.Mark(folder.SharedWithMark(), viewList(
+				folder.SharedDevices,
+				folderStyleInnerWidth-lipgloss.Width("Locally Changed Items")-1,
+				allShares,
+			))),
+		}
+		if fsType := filesystemType(folder.Config); fsType != "basic" {
+			bottomRows = append(bottomRows, lo.T2("Filesystem Type", fsType))
+		}
+		if encryption := folderEncryptionLabel(folder.Config); encryption != "" {
+			bottomRows = append(bottomRows, lo.T2("Encryption", encryption))
+		}
+		if folder.Config.IgnorePerms || folder.PermissionsUnsupported {
+			bottomRows = append(bottomRows, lo.T2("Ignore Permissions", ignorePermsLabel(folder)))
+		}
+		if folder.Config.FsWatcherEnabled && folder.Status.WatchError != "" {
+			bottomRows = append(bottomRows, lo.T2("Watch Error", lipgloss.NewStyle().
+				Foreground(styles.WarningColor).
+				Render(folder.Status.WatchError)))
+		}
+		if folder.Conflicts.Count > 0 {
+			bottomRows = append(bottomRows, lo.T2("Conflicts", lipgloss.NewStyle().
+				Foreground(styles.WarningColor).
+				Render(fmt.Sprintf("%d files", folder.Conflicts.Count))))
+		}
+		if hasStats {
+			bottomRows = append(bottomRows,
+				lo.T2("Last Scan",
+					FormatInstant(folder.ExtraStats.LastScan, currentTime, timeStyle)),
+				lo.T2("Next Scan", nextScanLabel(folder, currentTime)),
+				lo.T2("Last File", fmt.Sprint(folder.ExtraStats.LastFile.Filename)),
+			)
 		}
 
 		bar := spaceAroundTable().Width(folderStyleInnerWidth)
@@ -1238,6 +3707,9 @@ func viewFolder(
 			bar = bar.Row(r.Unpack())
 		}
 		verticalViews = append(verticalViews, bar.Render())
+		if scanPrompt != "" {
+			verticalViews = append(verticalViews, "", scanPrompt)
+		}
 
 		var footer string
 		{
@@ -1248,33 +3720,62 @@ func viewFolder(
 				Mark(folder.TogglePauseMark(),
 					styles.BtnStyleV2.
 						Render(lo.Ternary(
-							folderStatus(folder) == Paused,
+							status == Paused,
 							"Resume",
 							"Pause",
 						)))
 			rescanBtn := zone.
 				Mark(folder.RescanMark(),
 					styles.BtnStyleV2.Render("Rescan"))
+			if status == Scanning {
+				rescanBtn = styles.BtnStyleV2.Faint(true).Render("Scanning…")
+			}
 
-			gap := strings.Repeat(
-				" ",
-				folderStyleInnerWidth-
-					lipgloss.Width(revertLocalChangesBtn)-
-					lipgloss.Width(pauseBtn)-
-					lipgloss.Width(rescanBtn))
-
+			leftBtns := []string{zone.Mark(folder.RemoveFolderMark(),
+				styles.NegativeBtn.Render("Remove"))}
+			rightBtns := make([]string, 0)
 			if status == LocalAdditions || status == LocalUnencrypted {
-				footer = lipgloss.JoinHorizontal(
-					lipgloss.Top,
-					revertLocalChangesBtn,
-					gap,
-					pauseBtn,
-					rescanBtn,
-				)
-			} else {
-				alignRight := lipgloss.NewStyle().Align(lipgloss.Right).Width(folderStyleInnerWidth)
-				footer = alignRight.Render(lipgloss.JoinHorizontal(lipgloss.Top, pauseBtn, rescanBtn))
+				leftBtns = append(leftBtns, revertLocalChangesBtn)
+			}
+			if overridable(folder) {
+				leftBtns = append(leftBtns, zone.Mark(folder.OverrideChangesMark(),
+					styles.NegativeBtn.Render("Override Changes")))
+			}
+			if folder.Conflicts.Count > 0 {
+				leftBtns = append(leftBtns, zone.Mark(folder.ConflictsMark(),
+					styles.BtnStyleV2.Render("Conflicts")))
+			}
+			leftBtns = append(leftBtns, zone.Mark(folder.IgnoresMark(),
+				styles.BtnStyleV2.Render("Ignores")))
+			leftBtns = append(leftBtns, zone.Mark(folder.AdvancedMark(),
+				styles.BtnStyleV2.Render("Advanced")))
+			leftBtns = append(leftBtns, zone.Mark(folder.InviteMark(),
+				styles.BtnStyleV2.Render("Invite")))
+			if len(folder.Config.Devices) > 1 {
+				rightBtns = append(rightBtns, zone.Mark(folder.EncryptionMark(),
+					styles.BtnStyleV2.Render("Encryption")))
+			}
+			if folder.Config.IgnorePerms || folder.PermissionsUnsupported {
+				rightBtns = append(rightBtns, zone.Mark(folder.IgnorePermsMark(),
+					styles.BtnStyleV2.Render(lo.Ternary(
+						folder.Config.IgnorePerms,
+						"Keep Perms",
+						"Ignore Perms",
+					))))
 			}
+			if status == Error &&
+				classifyFolderFatal(folderFatalCause(folder)) == FolderFatalMarkerMissing {
+				rightBtns = append(rightBtns, zone.Mark(folder.CreateMarkerMark(),
+					styles.BtnStyleV2.Render("Create Marker")))
+			}
+			rightBtns = append(rightBtns, pauseBtn)
+			if status != Scanning {
+				rightBtns = append(rightBtns, zone.Mark(folder.RescanPathMark(),
+					styles.BtnStyleV2.Render("Rescan Path")))
+			}
+			rightBtns = append(rightBtns, rescanBtn)
+
+			footer = viewFooter(folderStyleInnerWidth, leftBtns, rightBtns)
 		}
 
 		verticalViews = append(verticalViews, "")
@@ -1284,26 +3785,155 @@ func viewFolder(
 	return folderStyle.Render(lipgloss.JoinVertical(lipgloss.Left, verticalViews...))
 }
 
-func viewDevices(devices []DeviceViewModel, currentTime time.Time,
+// ignorePermsLabel warns when permissions are kept on a filesystem that can't store them,
+// which makes syncthing detect changes on every scan.
+func ignorePermsLabel(folder FolderViewModel) string {
+	if folder.Config.IgnorePerms {
+		return "Yes"
+	}
+
+	return lipgloss.NewStyle().
+		Foreground(styles.WarningColor).
+		Render("No ⚠ filesystem doesn't keep permissions")
+}
+
+func nextScanLabel(folder FolderViewModel, currentTime time.Time) string {
+	// a failing watcher leaves only the periodic rescans
+	if folder.Config.FsWatcherEnabled && folder.Status.WatchError == "" {
+		return "Watching for changes"
+	}
+
+	if folder.Config.RescanIntervalS <= 0 || folder.ExtraStats.LastScan.IsZero() {
+		return "-"
+	}
+
+	nextScan := folder.ExtraStats.LastScan.Add(
+		time.Duration(folder.Config.RescanIntervalS) * time.Second,
+	)
+	remaining := nextScan.Sub(currentTime)
+	if remaining <= 0 {
+		return "Due"
+	}
+
+	return "in " + Countdown(remaining)
+}
+
+// folderSyncPercent returns how much of the global state is already present locally.
+// Folders made only of directories or empty files have no global bytes, in that
+// case progress is measured in items instead.
+func folderSyncPercent(status syncthing.FolderStatus) float64 {
+	if status.GlobalBytes > 0 {
+		return float64(status.GlobalBytes-status.NeedBytes) / float64(status.GlobalBytes) * 100
+	}
+
+	if status.GlobalTotalItems > 0 {
+		return float64(
+			status.GlobalTotalItems-status.NeedTotalItems,
+		) / float64(
+			status.GlobalTotalItems,
+		) * 100
+	}
+
+	return 100
+}
+
+// viewFooter renders left and right aligned buttons, breaking into two lines when
+// they don't fit side by side.
+func viewFooter(width int, left, right []string) string {
+	leftView := lipgloss.JoinHorizontal(lipgloss.Top, left...)
+	rightView := lipgloss.JoinHorizontal(lipgloss.Top, right...)
+	alignRight := lipgloss.NewStyle().Align(lipgloss.Right).Width(width)
+	if len(left) == 0 {
+		return alignRight.Render(rightView)
+	}
+
+	gap := width - lipgloss.Width(leftView) - lipgloss.Width(rightView)
+	if gap < 0 {
+		return lipgloss.JoinVertical(lipgloss.Left, leftView, alignRight.Render(rightView))
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, leftView, strings.Repeat(" ", gap), rightView)
+}
+
+func folderEncryptionLabel(config syncthing.FolderConfig) string {
+	if config.Type == "receiveencrypted" {
+		return "Encrypted at rest here"
+	}
+
+	encrypted := lo.CountBy(config.Devices, func(d syncthing.FolderDevice) bool {
+		return d.EncryptionPassword != ""
+	})
+	if encrypted == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("Encrypted for %d device(s)", encrypted)
+}
+
+func viewDevices(devices []DeviceViewModel, currentTime time.Time, timeStyle TimeStyle,
+	sizeStyle SizeStyle,
 	expandedFields map[string]struct{},
+	hasStats bool,
+	selectedID string,
+	groups map[string]string,
+	grouped bool,
+	pinned []string,
 ) string {
-	views := lo.Map(devices, func(device DeviceViewModel, index int) string {
-		_, has := expandedFields[device.Config.DeviceID]
-		return viewDevice(device, currentTime, has)
-	})
+	viewList := func(devices []DeviceViewModel) []string {
+		return lo.Map(devices, func(device DeviceViewModel, index int) string {
+			_, has := expandedFields[device.Config.DeviceID]
+			_, allFolders := expandedFields[device.FoldersMark()]
+			selected := device.Config.DeviceID == selectedID
+			isPinned := lo.Contains(pinned, device.Config.DeviceID)
+			return viewDevice(
+				device,
+				currentTime,
+				timeStyle,
+				sizeStyle,
+				has,
+				allFolders,
+				hasStats,
+				selected,
+				isPinned,
+			)
+		})
+	}
+
+	if !grouped {
+		return lipgloss.JoinVertical(lipgloss.Left, viewList(devices)...)
+	}
+
+	views := make([]string, 0, len(devices))
+	groupStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.AccentColor).PaddingLeft(1)
+	for _, group := range groupDevices(devices, groups) {
+		name, members := group.Unpack()
+		views = append(views, groupStyle.Render(fmt.Sprintf("%s (%d)", name, len(members))))
+		views = append(views, viewList(members)...)
+	}
 
 	return lipgloss.JoinVertical(lipgloss.Left, views...)
 }
 
-func viewDevice(device DeviceViewModel, currentTime time.Time, expanded bool) string {
+func viewDevice(
+	device DeviceViewModel,
+	currentTime time.Time,
+	timeStyle TimeStyle,
+	sizeStyle SizeStyle,
+	expanded bool,
+	// list every shared folder instead of fitting them on a line
+	allFolders bool,
+	hasStats bool,
+	selected bool,
+	pinned bool,
+) string {
 	status := deviceStatus(device, currentTime)
 	color := deviceColor(status)
-	container := lipgloss.NewStyle().
+	container := selectedBorder(lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		PaddingLeft(1).
 		PaddingRight(1).
 		Width(50).
-		BorderForeground(color)
+		BorderForeground(color), selected)
 	groupedCompletion := groupCompletion(device.StatusCompletion)
 
 	containerInnerWidth := container.GetWidth() - container.GetHorizontalPadding()
@@ -1313,14 +3943,14 @@ func viewDevice(device DeviceViewModel, currentTime time.Time, expanded bool) st
 			"%s (%0.f%%, %s)",
 			deviceLabel(status),
 			groupedCompletion.Completion,
-			humanize.IBytes(uint64(groupedCompletion.NeedBytes)))
+			humanBytes(groupedCompletion.NeedBytes))
 	} else {
 		deviceStatusLabel = deviceLabel(status)
 	}
 
 	header := lipgloss.NewStyle().Bold(true).Render(
 		zone.Mark(device.HeaderMark(), spaceAroundTable().Width(containerInnerWidth).
-			Row(device.Config.Name,
+			Row(pinLabel(device.Config.Name, pinned),
 				lipgloss.
 					NewStyle().
 					Foreground(color).
@@ -1342,43 +3972,138 @@ func viewDevice(device DeviceViewModel, currentTime time.Time, expanded bool) st
 	if device.Connection.B.Connected {
 		table.Row("Download Rate",
 			fmt.Sprintf("%s/s (%s)",
-				humanize.IBytes(uint64(device.InGoingBytesPerSecond)),
-				humanize.IBytes(uint64(device.Connection.B.InBytesTotal)),
+				humanBytes(device.InGoingBytesPerSecond),
+				FormatSize(device.Connection.B.InBytesTotal, sizeStyle),
 			),
 		).
 			Row("Upload Rate",
 				fmt.Sprintf("%s/s (%s)",
-					humanize.IBytes(uint64(device.OutGoingBytesPerSecond)),
-					humanize.IBytes(uint64(device.Connection.B.OutBytesTotal)),
+					humanBytes(device.OutGoingBytesPerSecond),
+					FormatSize(device.Connection.B.OutBytesTotal, sizeStyle),
 				),
 			)
+		if !device.Connection.B.StartedAt.IsZero() {
+			table.Row("Connected For",
+				FormatSince(device.Connection.B.StartedAt, currentTime, timeStyle))
+			sessionIn, sessionOut := device.SessionBytes()
+			table.Row("Session Traffic",
+				fmt.Sprintf("↓ %s ↑ %s",
+					FormatSize(sessionIn, sizeStyle),
+					FormatSize(sessionOut, sizeStyle),
+				))
+		}
 		if status == DeviceSyncing {
 			table.Row("Out of Sync Items", fmt.Sprint(groupedCompletion.NeedItems))
 		}
 	} else {
-		table.
-			Row("Last Seen", device.ExtraStats.LastSeen.Format(time.DateTime))
+		if hasStats {
+			table.Row("Last Seen",
+				FormatInstant(device.ExtraStats.LastSeen, currentTime, timeStyle))
+			if device.ExtraStats.LastConnectionDurationS > 0 {
+				table.Row("Last Connection",
+					HumanizeDuration(int64(device.ExtraStats.LastConnectionDurationS)))
+			}
+		}
 
 		if groupedCompletion.NeedBytes > 0 {
 			table.Row("Sync Status", fmt.Sprintf("%0.f%%", groupedCompletion.Completion))
 			table.Row("Out of Sync Items",
 				fmt.Sprintf("%d items, ~%s",
 					groupedCompletion.NeedItems,
-					humanize.IBytes(uint64(groupedCompletion.NeedBytes))))
+					FormatSize(groupedCompletion.NeedBytes, sizeStyle)))
 		} else {
 			table.Row("Sync Status", "Up to Date")
 		}
 
 	}
-	table.Row("Address", device.Connection.B.Address).
-		Row("Compresson", device.Config.Compression).
-		Row("Identification", shortIdentification(device.Config.DeviceID)).
-		Row("Version", (device.Connection.B.ClientVersion)).
-		Row("Folders", strings.Join(sharedFolders, ", ")).
-		Render()
+	// devices missing from the connections response have no connection data to show
+	if device.Connection.A {
+		table.Row("Address", device.Connection.B.Address)
+		if device.Connection.B.Connected {
+			table.Row("Connection Type", connectionTypeLabel(device.Connection.B))
+		}
+		if device.Connection.B.Primary != nil {
+			table.Row("Connections",
+				fmt.Sprintf("%d active", device.Connection.B.NumConnections()))
+		}
+	}
+	if uptime, ok := device.ConnectionHistory.UptimePercent(currentTime); ok {
+		table.Row("Observed Uptime",
+			fmt.Sprintf("%.0f%% (%d drops)", uptime, device.ConnectionHistory.Disconnects))
+	}
+	table.Row("Compresson", device.Config.Compression).
+		Row("Identification", shortIdentification(device.Config.DeviceID))
+	if device.Connection.A {
+		table.Row("Version", device.Connection.B.ClientVersion)
+	}
+	// the label column is as wide as the longest label of the card
+	table.Row("Folders", zone.Mark(device.FoldersMark(), viewList(
+		sharedFolders,
+		containerInnerWidth-lipgloss.Width("Out of Sync Items")-1,
+		allFolders,
+	)))
+	if device.Config.Untrusted {
+		table.Row("Untrusted", "Yes")
+	}
 	content := table.Render()
 
-	return container.Render(lipgloss.JoinVertical(lipgloss.Left, header, content))
+	views := lo.Compact([]string{
+		header,
+		content,
+		viewDeviceFolderCompletion(device, sizeStyle, containerInnerWidth),
+	})
+	if device.Config.Untrusted && len(device.FoldersMissingPassword) > 0 {
+		warning := lipgloss.NewStyle().
+			Foreground(styles.WarningColor).
+			Width(containerInnerWidth).
+			Render(fmt.Sprintf("⚠ Untrusted device but no encryption password set for: %s",
+				strings.Join(device.FoldersMissingPassword, ", ")))
+		views = append(views, "", warning)
+	}
+
+	untrustedBtn := zone.Mark(device.ToggleUntrustedMark(),
+		styles.BtnStyleV2.Render(
+			lo.Ternary(device.Config.Untrusted, "Mark Trusted", "Mark Untrusted"),
+		))
+	actions := untrustedBtn
+	if _, ok := device.RemoteGUIURL(); ok {
+		remoteGUIBtn := zone.Mark(device.RemoteGUIMark(), styles.BtnStyleV2.Render("Remote GUI"))
+		actions = lipgloss.JoinHorizontal(lipgloss.Top, remoteGUIBtn, "  ", untrustedBtn)
+	}
+	removeBtn := zone.Mark(device.RemoveMark(), styles.NegativeBtn.Render("Remove"))
+	footer := viewFooter(containerInnerWidth, []string{removeBtn}, []string{actions})
+	views = append(views, "", footer)
+
+	return container.Render(lipgloss.JoinVertical(lipgloss.Left, views...))
+}
+
+// viewDeviceFolderCompletion tells which shared folders the device is behind on.
+// Folders without completion yet are left out rather than shown at 0%.
+func viewDeviceFolderCompletion(device DeviceViewModel, sizeStyle SizeStyle, width int) string {
+	table := spaceAroundTable().Width(width)
+	rows := 0
+	for _, f := range device.Folders {
+		completion, has := device.StatusCompletion[f.A]
+		if !has {
+			continue
+		}
+
+		label := fmt.Sprintf("%0.f%%", math.Floor(completion.Completion))
+		if completion.NeedBytes > 0 {
+			label = lipgloss.NewStyle().Foreground(styles.WarningColor).Render(
+				fmt.Sprintf("%s, %s needed", label, FormatSize(completion.NeedBytes, sizeStyle)))
+		}
+		table.Row(f.B, label)
+		rows++
+	}
+	if rows == 0 {
+		return ""
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.NewStyle().PaddingTop(1).Bold(true).Render("Folder Completion"),
+		table.Render(),
+	)
 }
 
 type GroupedCompletion struct {
@@ -1458,6 +4183,30 @@ func archName(arch string) string {
 	return "unknown arch"
 }
 
+// connectionTypeLabel tells direct LAN or WAN connections from relayed ones, along
+// with the transport. syncthing types look like "tcp-client" or "relay-server".
+func connectionTypeLabel(connection syncthing.Connection) string {
+	transport, _, _ := strings.Cut(connection.Type, "-")
+	network := lo.Ternary(connection.IsLocal, "LAN", "WAN")
+	var label string
+	switch transport {
+	case "tcp":
+		label = network + " (TCP)"
+	case "quic":
+		label = network + " (QUIC)"
+	case "relay":
+		// relays carry the connection over TCP
+		label = "Relay (TCP)"
+	default:
+		label = connection.Type
+	}
+
+	if connection.IsLocal {
+		return lipgloss.NewStyle().Foreground(styles.SuccessColor).Render("⌂ " + label)
+	}
+	return label
+}
+
 func shortIdentification(id string) string {
 	dashIndex := strings.Index(id, "-")
 	return strings.ToUpper(id[0:dashIndex])
@@ -1480,6 +4229,16 @@ const (
 	Unknown
 )
 
+// optimisticFolder applies a not yet confirmed pause/resume to the folder config
+// so the card reflects the user action right away.
+func optimisticFolder(folder FolderViewModel) FolderViewModel {
+	if folder.PendingPause.A {
+		folder.Config.Paused = folder.PendingPause.B
+	}
+
+	return folder
+}
+
 func folderStatus(folder FolderViewModel) FolderStatus {
 	if folder.Status.State == "syncing" {
 		return Syncing
@@ -1539,13 +4298,13 @@ const (
 func deviceStatus(device DeviceViewModel, currentTime time.Time) DeviceStatus {
 	isUnused := len(device.Folders) == 0
 
+	// the config tells a paused device before its connection state is known
+	if devicePaused(device) {
+		return lo.Ternary(isUnused, DeviceUnusedPaused, DevicePaused)
+	}
 	if !device.Connection.A {
 		return DeviceUnknown
 	}
-
-	if device.Config.Paused {
-		return lo.Ternary(isUnused, DeviceUnusedPaused, DevicePaused)
-	}
 	if device.Connection.B.Connected {
 		insync := lo.Ternary(isUnused, DeviceUnusedInSync, DeviceInSync)
 		groupedCompletion := groupCompletion(device.StatusCompletion)
@@ -1569,6 +4328,16 @@ func deviceStatus(device DeviceViewModel, currentTime time.Time) DeviceStatus {
 	}
 }
 
+// devicePaused prefers the live connection state, which is kept in line with
+// config changes as they arrive, over the config alone.
+func devicePaused(device DeviceViewModel) bool {
+	if device.Connection.A {
+		return device.Connection.B.Paused
+	}
+
+	return device.Config.Paused
+}
+
 func deviceLabel(state DeviceStatus) string {
 	switch state {
 	case DeviceDisconnected:
@@ -1619,6 +4388,27 @@ func deviceColor(state DeviceStatus) lipgloss.AdaptiveColor {
 	return lipgloss.AdaptiveColor{}
 }
 
+func folderHasProblem(status FolderStatus) bool {
+	return status == Error || status == OutOfSync || status == FailedItems
+}
+
+// viewFolderBehind is what the local state misses to match the global state,
+// empty when the folder is in sync.
+func viewFolderBehind(status syncthing.FolderStatus, sizeStyle SizeStyle) string {
+	if status.NeedTotalItems == 0 && status.NeedBytes == 0 {
+		return ""
+	}
+
+	behind := fmt.Sprintf("📄 %d 📁 %d 📁 %s",
+		status.NeedFiles,
+		status.NeedDirectories,
+		FormatSize(status.NeedBytes, sizeStyle))
+	if status.NeedDeletes > 0 {
+		behind += fmt.Sprintf(", %d deletes", status.NeedDeletes)
+	}
+	return behind
+}
+
 func folderStatusLabel(foo FolderStatus) string {
 	switch foo {
 	case Idle:
@@ -1729,9 +4519,33 @@ func byteThroughputInSeconds(before, after TotalBytes) int64 {
 	deltaBytes := after.bytes - before.bytes
 	deltaTime := int64(after.at.Sub(before.at).Seconds())
 
-	if deltaTime == 0 {
+	// the totals went down when syncthing restarted or another host answered
+	if deltaTime == 0 || deltaBytes < 0 {
 		return 0
 	}
 
 	return deltaBytes / deltaTime
 }
+
+// humanBytes formats a byte count, showing counter glitches below zero as 0 B
+// instead of wrapping around to exabytes.
+func humanBytes(bytes int64) string {
+	return humanize.IBytes(uint64(max(bytes, 0)))
+}
+
+type SizeStyle int
+
+const (
+	SizeHuman SizeStyle = iota
+	// the humanized size followed by the exact byte count
+	SizeExact
+)
+
+// FormatSize adds the exact thousands separated byte count when asked, as
+// "1.2 GiB" is too imprecise to check transfer amounts.
+func FormatSize(bytes int64, style SizeStyle) string {
+	if style == SizeExact {
+		return fmt.Sprintf("%s (%s B)", humanBytes(bytes), humanize.Comma(max(bytes, 0)))
+	}
+	return humanBytes(bytes)
+}
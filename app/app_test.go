@@ -0,0 +1,391 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/pdrolopes/syncthing_TUI/syncthing"
+	"github.com/samber/lo"
+)
+
+func TestMain(m *testing.M) {
+	// the views and modals mark their zones like in the program
+	zone.NewGlobal()
+	// NewModel loads the settings, keep the user ones out of the tests
+	dir, err := os.MkdirTemp("", "syncthing_TUI")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("XDG_CONFIG_HOME", dir)
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+func TestDeviceStatus(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	folders := []lo.Tuple2[string, string]{lo.T2("default", "Default Folder")}
+	tests := []struct {
+		name   string
+		device DeviceViewModel
+		want   DeviceStatus
+	}{
+		{
+			name:   "unknown connection",
+			device: DeviceViewModel{Folders: folders},
+			want:   DeviceUnknown,
+		},
+		{
+			name: "paused in the config, unknown connection",
+			device: DeviceViewModel{
+				Config:  syncthing.DeviceConfig{Paused: true},
+				Folders: folders,
+			},
+			want: DevicePaused,
+		},
+		{
+			name: "unused and paused in the config",
+			device: DeviceViewModel{
+				Config: syncthing.DeviceConfig{Paused: true},
+			},
+			want: DeviceUnusedPaused,
+		},
+		{
+			name: "connection paused before the config",
+			device: DeviceViewModel{
+				Connection: lo.T2(true, syncthing.Connection{Paused: true}),
+				Folders:    folders,
+			},
+			want: DevicePaused,
+		},
+		{
+			name: "connection resumed before the config",
+			device: DeviceViewModel{
+				Config:     syncthing.DeviceConfig{Paused: true},
+				Connection: lo.T2(true, syncthing.Connection{Connected: true}),
+				Folders:    folders,
+			},
+			want: DeviceInSync,
+		},
+		{
+			name: "disconnected",
+			device: DeviceViewModel{
+				Connection: lo.T2(true, syncthing.Connection{}),
+				ExtraStats: syncthing.DeviceStats{LastSeen: now.Add(-time.Hour)},
+				Folders:    folders,
+			},
+			want: DeviceDisconnected,
+		},
+		{
+			name: "disconnected for over a week",
+			device: DeviceViewModel{
+				Connection: lo.T2(true, syncthing.Connection{}),
+				ExtraStats: syncthing.DeviceStats{LastSeen: now.Add(-8 * 24 * time.Hour)},
+				Folders:    folders,
+			},
+			want: DeviceDisconnectedInactive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := deviceStatus(tt.device, now); got != tt.want {
+				t.Errorf("deviceStatus() = %v, want %v", deviceLabel(got), deviceLabel(tt.want))
+			}
+		})
+	}
+}
+
+func TestFolderSyncPercent(t *testing.T) {
+	tests := []struct {
+		name   string
+		status syncthing.FolderStatus
+		want   float64
+	}{
+		{
+			name:   "bytes",
+			status: syncthing.FolderStatus{GlobalBytes: 200, NeedBytes: 50, GlobalTotalItems: 4},
+			want:   75,
+		},
+		{
+			name:   "zero bytes with items",
+			status: syncthing.FolderStatus{GlobalTotalItems: 8, NeedTotalItems: 2},
+			want:   75,
+		},
+		{
+			name:   "zero bytes all items present",
+			status: syncthing.FolderStatus{GlobalTotalItems: 8},
+			want:   100,
+		},
+		{name: "empty folder", status: syncthing.FolderStatus{}, want: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := folderSyncPercent(tt.status)
+			if math.IsNaN(got) || math.IsInf(got, 0) || got != tt.want {
+				t.Errorf("folderSyncPercent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := []struct {
+		name  string
+		bytes int64
+		style SizeStyle
+		want  string
+	}{
+		{name: "zero", bytes: 0, style: SizeHuman, want: "0 B"},
+		{name: "kibibytes", bytes: 2048, style: SizeHuman, want: "2.0 KiB"},
+		{name: "negative", bytes: -1, style: SizeHuman, want: "0 B"},
+		{name: "most negative", bytes: math.MinInt64, style: SizeHuman, want: "0 B"},
+		{name: "exact", bytes: 1234567, style: SizeExact, want: "1.2 MiB (1,234,567 B)"},
+		{name: "exact negative", bytes: -2048, style: SizeExact, want: "0 B (0 B)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatSize(tt.bytes, tt.style); got != tt.want {
+				t.Errorf("FormatSize(%d) = %q, want %q", tt.bytes, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestViewDeviceConnectionRows(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	config := syncthing.DeviceConfig{DeviceID: "AAAAAAA-BBBBBBB", Name: "laptop"}
+	tests := []struct {
+		name       string
+		connection lo.Tuple2[bool, syncthing.Connection]
+		want       bool
+	}{
+		{name: "absent from the connections map", want: false},
+		{
+			name: "disconnected",
+			connection: lo.T2(true, syncthing.Connection{
+				Address:       "192.0.2.1:22000",
+				ClientVersion: "v1.29.0",
+			}),
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device := DeviceViewModel{Config: config, Connection: tt.connection}
+			view := viewDevice(
+				device, now, TimeRelative, SizeHuman, true, false, true, false, false)
+			for _, row := range []string{"Address", "Version"} {
+				if got := strings.Contains(view, row); got != tt.want {
+					t.Errorf("viewDevice() shows the %s row = %v, want %v\n%s",
+						row, got, tt.want, view)
+				}
+			}
+			if !strings.Contains(view, "Identification") {
+				t.Errorf("viewDevice() lost the config rows\n%s", view)
+			}
+		})
+	}
+}
+
+func TestUpdateSubComponents(t *testing.T) {
+	folder := FolderViewModel{Config: syncthing.FolderConfig{ID: "default"}}
+	loaded := FetchedIgnoresMsg{
+		folderID: "default",
+		ignores:  syncthing.Ignores{Ignore: []string{"*.tmp"}},
+	}
+	tests := []struct {
+		name       string
+		setup      func(m model) model
+		wantShow   bool
+		wantCmd    bool
+		wantPrompt bool
+	}{
+		{
+			name:  "no active component",
+			setup: func(m model) model { return m },
+		},
+		{
+			name: "active modal",
+			setup: func(m model) model {
+				m.folderIgnoresModal = NewFolderIgnores(folder, m.httpData)
+				return m
+			},
+			wantShow: true,
+			wantCmd:  true,
+		},
+		{
+			name: "active modal after another active component",
+			setup: func(m model) model {
+				m.folderIgnoresModal = NewFolderIgnores(folder, m.httpData)
+				m.scanPrompt = NewScanPrompt(folder)
+				return m
+			},
+			wantShow:   true,
+			wantCmd:    true,
+			wantPrompt: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.setup(NewModel(Options{}))
+			updated, cmd := m.Update(loaded)
+			m = updated.(model)
+
+			if m.folderIgnoresModal.Show != tt.wantShow {
+				t.Errorf("ignores modal shown = %v, want %v",
+					m.folderIgnoresModal.Show, tt.wantShow)
+			}
+			if tt.wantShow && m.folderIgnoresModal.input.Value() != "*.tmp" {
+				t.Errorf("ignores modal didn't get the message, value %q",
+					m.folderIgnoresModal.input.Value())
+			}
+			if (cmd != nil) != tt.wantCmd {
+				t.Errorf("Update() cmd = %v, want a cmd %v", cmd, tt.wantCmd)
+			}
+			if m.scanPrompt.Active() != tt.wantPrompt {
+				t.Errorf("scan prompt active = %v, want %v", m.scanPrompt.Active(), tt.wantPrompt)
+			}
+		})
+	}
+}
+
+// updateAll feeds msgs through Update in order, dropping the commands.
+func updateAll(m model, msgs ...tea.Msg) model {
+	for _, msg := range msgs {
+		updated, _ := m.Update(msg)
+		m = updated.(model)
+	}
+
+	return m
+}
+
+func localAndRemoteConfig() syncthing.Config {
+	return syncthing.Config{
+		Devices: []syncthing.DeviceConfig{
+			{DeviceID: "LOCAL", Name: "desktop"},
+			{DeviceID: "REMOTE", Name: "laptop"},
+		},
+	}
+}
+
+func TestLocalDeviceDropped(t *testing.T) {
+	config := FetchedConfig{config: localAndRemoteConfig()}
+	status := FetchedSystemStatusMsg{status: syncthing.SystemStatus{MyID: "LOCAL"}}
+	tests := []struct {
+		name string
+		msgs []tea.Msg
+	}{
+		{name: "config before status", msgs: []tea.Msg{config, status}},
+		{name: "status before config", msgs: []tea.Msg{status, config}},
+		{name: "config again after status", msgs: []tea.Msg{config, status, config}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := updateAll(NewModel(Options{}), tt.msgs...)
+			ids := lo.Map(m.devices, func(d DeviceViewModel, index int) string {
+				return d.Config.DeviceID
+			})
+			if !reflect.DeepEqual(ids, []string{"REMOTE"}) {
+				t.Errorf("devices = %v, want only the remote one", ids)
+			}
+		})
+	}
+}
+
+func TestThisDeviceName(t *testing.T) {
+	config := FetchedConfig{config: localAndRemoteConfig()}
+	status := FetchedSystemStatusMsg{status: syncthing.SystemStatus{MyID: "LOCAL"}}
+	tests := []struct {
+		name string
+		msgs []tea.Msg
+		want string
+	}{
+		{name: "config only", msgs: []tea.Msg{config}, want: "no-name"},
+		{name: "config before status", msgs: []tea.Msg{config, status}, want: "desktop"},
+		{name: "status before config", msgs: []tea.Msg{status, config}, want: "desktop"},
+		{
+			name: "status polled again",
+			msgs: []tea.Msg{config, status, status},
+			want: "desktop",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := updateAll(NewModel(Options{}), tt.msgs...)
+			if m.thisDeviceStatus.Name != tt.want {
+				t.Errorf("this device name = %q, want %q", m.thisDeviceStatus.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestSavedEncryptionPasswords(t *testing.T) {
+	folder := FolderViewModel{Config: syncthing.FolderConfig{ID: "default"}}
+	saved := SavedEncryptionPasswordsMsg{folderID: "default"}
+	failed := SavedEncryptionPasswordsMsg{folderID: "default", err: errors.New("forbidden")}
+	tests := []struct {
+		name       string
+		modal      bool
+		msg        SavedEncryptionPasswordsMsg
+		wantShow   bool
+		wantBanner bool
+	}{
+		{name: "saved", modal: true, msg: saved},
+		{name: "failed in the modal", modal: true, msg: failed, wantShow: true},
+		{name: "failed after the modal closed", msg: failed, wantBanner: true},
+		{name: "saved after the modal closed", msg: saved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewModel(Options{})
+			m.encryptionModal = NewEncryptionPasswords(folder, nil, "", m.httpData)
+			m.encryptionModal.saving = true
+			m.encryptionModal.Show = tt.modal
+			m = updateAll(m, tt.msg)
+
+			if m.encryptionModal.Show != tt.wantShow {
+				t.Errorf("modal shown = %v, want %v", m.encryptionModal.Show, tt.wantShow)
+			}
+			if tt.wantShow && m.encryptionModal.err == nil {
+				t.Errorf("modal doesn't show the failure")
+			}
+			if got := m.errBanner.Visible(m.currentTime); got != tt.wantBanner {
+				t.Errorf("error banner visible = %v, want %v", got, tt.wantBanner)
+			}
+		})
+	}
+}
+
+func TestEventsNotFound(t *testing.T) {
+	m := NewModel(Options{})
+	err := fmt.Errorf("GET %s failed: %w", EVENTS, errNotFound)
+	updated, cmd := m.Update(FetchedEventsMsg{err: err, since: 7})
+	m = updated.(model)
+
+	if cmd == nil {
+		t.Error("Update() gave up on the events after a 404, want a retry")
+	}
+	if !m.isEndpointAvailable(EVENTS) {
+		t.Error("events marked unavailable after a 404")
+	}
+	if !m.errBanner.Visible(m.currentTime) {
+		t.Error("the 404 isn't shown in the error banner")
+	}
+}
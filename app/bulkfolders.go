@@ -0,0 +1,177 @@
+package app
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+	"github.com/samber/lo"
+)
+
+const (
+	REVERT_ALL_MARK         = "revert-all"
+	OVERRIDE_ALL_MARK       = "override-all"
+	BULK_FOLDER_MODAL_AREA  = "bulk-folder-action-modal"
+	BULK_FOLDER_CONFIRM_BTN = "confirm-bulk-folder-action"
+	BULK_FOLDER_CANCEL_BTN  = "cancel-bulk-folder-action"
+)
+
+type BulkFolderAction int
+
+const (
+	BulkRevert BulkFolderAction = iota
+	BulkOverride
+)
+
+// ConfirmBulkFolderAction holds the folders picked when the modal opened, so
+// the action only touches what the user confirmed.
+type ConfirmBulkFolderAction struct {
+	Show      bool
+	Action    BulkFolderAction
+	FolderIDs []string
+	Files     int
+	Bytes     int64
+}
+
+// revertableFolders are the receive only folders with local additions.
+func revertableFolders(folders []FolderViewModel) []FolderViewModel {
+	return lo.Filter(folders, func(f FolderViewModel, index int) bool {
+		return folderStatus(f) == LocalAdditions
+	})
+}
+
+// overridableFolders are the send only folders with changes made elsewhere.
+func overridableFolders(folders []FolderViewModel) []FolderViewModel {
+	return lo.Filter(folders, func(f FolderViewModel, index int) bool {
+		return overridable(f)
+	})
+}
+
+func newConfirmBulkFolderAction(
+	action BulkFolderAction,
+	folders []FolderViewModel,
+) ConfirmBulkFolderAction {
+	confirm := ConfirmBulkFolderAction{Action: action}
+	switch action {
+	case BulkRevert:
+		folders = revertableFolders(folders)
+		for _, f := range folders {
+			confirm.Files += f.Status.ReceiveOnlyChangedFiles
+			confirm.Bytes += f.Status.ReceiveOnlyChangedBytes
+		}
+	case BulkOverride:
+		folders = overridableFolders(folders)
+		for _, f := range folders {
+			confirm.Files += f.Status.NeedTotalItems
+			confirm.Bytes += f.Status.NeedBytes
+		}
+	}
+	confirm.FolderIDs = lo.Map(folders, func(f FolderViewModel, index int) string {
+		return f.Config.ID
+	})
+	confirm.Show = len(confirm.FolderIDs) > 0
+
+	return confirm
+}
+
+func viewBulkFolderBtns(folders []FolderViewModel) []string {
+	btns := make([]string, 0, 2)
+	if len(revertableFolders(folders)) > 0 {
+		btns = append(btns, zone.Mark(REVERT_ALL_MARK, styles.NegativeBtn.Render("Revert All")))
+	}
+	if len(overridableFolders(folders)) > 0 {
+		btns = append(btns,
+			zone.Mark(OVERRIDE_ALL_MARK, styles.NegativeBtn.Render("Override All")))
+	}
+
+	return btns
+}
+
+func viewConfirmBulkFolderAction(confirm ConfirmBulkFolderAction) string {
+	width := 60
+	title, btnLabel, text := "Revert All Local Changes", "Revert", fmt.Sprintf(
+		"The content of %d receive only folders will be overwritten to become identical "+
+			"with other devices. %d files (%s) added or changed here will be deleted or "+
+			"replaced.",
+		len(confirm.FolderIDs), confirm.Files, humanBytes(confirm.Bytes),
+	)
+	if confirm.Action == BulkOverride {
+		title, btnLabel, text = "Override All Changes", "Override", fmt.Sprintf(
+			"The content of %d send only folders will be enforced on the other devices. "+
+				"%d files (%s) changed elsewhere will be overwritten.",
+			len(confirm.FolderIDs), confirm.Files, humanBytes(confirm.Bytes),
+		)
+	}
+	header := lipgloss.NewStyle().
+		Padding(1, 1).
+		Width(width).
+		Background(styles.ErrorColor).
+		Render(title)
+	body := lipgloss.NewStyle().
+		Padding(1, 1).
+		Width(width).
+		Render("Warning!\n\n" + text + "\n\nAre you sure?")
+	var actions string
+	{
+		layout := lipgloss.NewStyle().Padding(0, 1).Width(width)
+		btnConfirm := zone.Mark(BULK_FOLDER_CONFIRM_BTN, styles.NegativeBtn.Render(btnLabel))
+		btnCancel := zone.Mark(BULK_FOLDER_CANCEL_BTN, styles.BtnStyleV2.Render("Cancel"))
+		actions = viewFooter(
+			layout.GetWidth()-layout.GetHorizontalPadding(),
+			[]string{btnConfirm},
+			[]string{btnCancel},
+		)
+		actions = layout.Render(actions)
+	}
+
+	return zone.Mark(
+		BULK_FOLDER_MODAL_AREA,
+		lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Render(
+			lipgloss.JoinVertical(lipgloss.Left, header, body, actions),
+		),
+	)
+}
+
+func (m model) startBulkFolderAction() (model, tea.Cmd) {
+	confirm := m.confirmBulkFolderAction
+	m.confirmBulkFolderAction = ConfirmBulkFolderAction{}
+
+	post := lo.Ternary(confirm.Action == BulkOverride, postOverrideChanges, postRevertChanges)
+	cmds := make([]tea.Cmd, 0, len(confirm.FolderIDs))
+	for _, folderID := range confirm.FolderIDs {
+		cmds = append(cmds, post(m.httpData, folderID))
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+func handleMouseEventsBulkFolderModal(m model, msg tea.MouseMsg) (model, tea.Cmd) {
+	if msg.Action != tea.MouseActionRelease || msg.Button != tea.MouseButtonLeft {
+		return m, nil
+	}
+
+	if zone.Get(BULK_FOLDER_CONFIRM_BTN).InBounds(msg) {
+		return m.startBulkFolderAction()
+	}
+
+	// cancel button or click out of modal bounds
+	if zone.Get(BULK_FOLDER_CANCEL_BTN).InBounds(msg) ||
+		!zone.Get(BULK_FOLDER_MODAL_AREA).InBounds(msg) {
+		m.confirmBulkFolderAction = ConfirmBulkFolderAction{}
+	}
+
+	return m, nil
+}
+
+func handleKeyBoardEventsBulkFolderModal(m model, msg tea.KeyMsg) (model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "n":
+		m.confirmBulkFolderAction = ConfirmBulkFolderAction{}
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	}
+
+	return m, nil
+}
@@ -3,10 +3,14 @@ package app
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"os/exec"
+	"path"
+	"runtime"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -20,6 +24,8 @@ const (
 	CONFIG_DEVICES          = "/rest/config/devices"
 	CONFIG_FOLDERS          = "/rest/config/folders"
 	DB_COMPLETION_PATH      = "/rest/db/completion"
+	DB_IGNORES              = "/rest/db/ignores"
+	DB_OVERRIDE             = "/rest/db/override"
 	DB_REVERT               = "/rest/db/revert"
 	DB_SCAN                 = "/rest/db/scan"
 	DB_STATUS               = "/rest/db/status"
@@ -27,10 +33,31 @@ const (
 	STATS_DEVICE            = "/rest/stats/device"
 	STATS_FOLDER            = "/rest/stats/folder"
 	SYSTEM_CONNECTIONS      = "/rest/system/connections"
+	SYSTEM_LOG              = "/rest/system/log"
+	SYSTEM_PATHS            = "/rest/system/paths"
+	SYSTEM_PAUSE            = "/rest/system/pause"
+	SYSTEM_PING             = "/rest/system/ping"
+	SYSTEM_RESUME           = "/rest/system/resume"
 	SYSTEM_STATUS           = "/rest/system/status"
+	SYSTEM_UPGRADE          = "/rest/system/upgrade"
 	SYSTEM_VERSION          = "/rest/system/version"
 )
 
+const (
+	// syncthing answers the long poll with no events after this long
+	EVENTS_POLL_TIMEOUT = 60 * time.Second
+	// extra time given to the http client before it gives up on the long poll
+	EVENTS_CLIENT_MARGIN = 10 * time.Second
+	EVENTS_RETRY_MIN     = time.Second
+	EVENTS_RETRY_MAX     = time.Minute
+)
+
+// ErrEndpointUnavailable is returned when syncthing answers 404 for an optional
+// endpoint, meaning the feature is not available on this instance.
+var ErrEndpointUnavailable = errors.New("endpoint unavailable")
+
+var errNotFound = errors.New("404 Not Found")
+
 func fetchFolderStatus(httpData HttpData, folderID string) tea.Cmd {
 	return func() tea.Msg {
 		params := url.Values{}
@@ -60,6 +87,12 @@ func fetchEvents(httpData HttpData, since int) tea.Cmd {
 	return func() tea.Msg {
 		params := url.Values{}
 		params.Add("since", fmt.Sprint(since))
+		params.Add("timeout", fmt.Sprint(int(EVENTS_POLL_TIMEOUT.Seconds())))
+		// the first request only primes the timeline, the older backlog isn't needed
+		if since == 0 {
+			params.Add("limit", fmt.Sprint(EVENT_LOG_SIZE))
+		}
+		httpData.client.Timeout = EVENTS_POLL_TIMEOUT + EVENTS_CLIENT_MARGIN
 		var events []syncthing.Event[json.RawMessage]
 		url := httpData.url.JoinPath(EVENTS)
 		url.RawQuery = params.Encode()
@@ -73,113 +106,98 @@ func fetchEvents(httpData HttpData, since int) tea.Cmd {
 		}
 
 		parsedEvents := make([]syncthing.Event[any], 0, len(events))
+		var decodeErrors []error
 		for _, e := range events {
-			switch e.Type {
-			case "FolderSummary":
-				var data syncthing.FolderSummaryEventData
-				err := json.Unmarshal(e.Data, &data)
-				if err != nil {
-					// TODO figure out how to handle this
-					continue
-				}
-
-				parsedEvents = append(parsedEvents, syncthing.Event[any]{
-					ID:       e.ID,
-					GlobalID: e.GlobalID,
-					Time:     e.Time,
-					Type:     e.Type,
-					Data:     data,
-				})
-			case "ConfigSaved":
-				var data syncthing.Config
-				err := json.Unmarshal(e.Data, &data)
-				if err != nil {
-					// TODO figure out how to handle this
-					continue
-				}
-
-				parsedEvents = append(parsedEvents, syncthing.Event[any]{
-					ID:       e.ID,
-					GlobalID: e.GlobalID,
-					Time:     e.Time,
-					Type:     e.Type,
-					Data:     data,
-				})
-			case "FolderScanProgress":
-				var data syncthing.FolderScanProgressEventData
-				err := json.Unmarshal(e.Data, &data)
-				if err != nil {
-					// TODO figure out how to handle this
-					continue
-				}
-
-				parsedEvents = append(parsedEvents, syncthing.Event[any]{
-					ID:       e.ID,
-					GlobalID: e.GlobalID,
-					Time:     e.Time,
-					Type:     e.Type,
-					Data:     data,
-				})
-			case "StateChanged":
-				var data syncthing.StateChangedEventData
-				err := json.Unmarshal(e.Data, &data)
-				if err != nil {
-					// TODO figure out how to handle this
-					continue
-				}
-
-				parsedEvents = append(parsedEvents, syncthing.Event[any]{
-					ID:       e.ID,
-					GlobalID: e.GlobalID,
-					Time:     e.Time,
-					Type:     e.Type,
-					Data:     data,
-				})
-			case "FolderCompletion":
-				var data syncthing.FolderCompletionEventData
-				er := json.Unmarshal(e.Data, &data)
-				if er != nil {
-					// TODO figure out how to handle this
-					err = er
-					continue
-				}
-
-				parsedEvents = append(parsedEvents, syncthing.Event[any]{
-					ID:       e.ID,
-					GlobalID: e.GlobalID,
-					Time:     e.Time,
-					Type:     e.Type,
-					Data:     data,
-				})
-			case "PendingDevicesChanged":
-				var data syncthing.PendingDevicesChangedEventData
-				er := json.Unmarshal(e.Data, &data)
-				if er != nil {
-					// TODO figure out how to handle this
-					err = er
-					continue
-				}
-
-				parsedEvents = append(parsedEvents, syncthing.Event[any]{
-					ID:       e.ID,
-					GlobalID: e.GlobalID,
-					Time:     e.Time,
-					Type:     e.Type,
-					Data:     data,
-				})
-			default:
-				parsedEvents = append(parsedEvents, syncthing.Event[any]{
-					ID:       e.ID,
-					GlobalID: e.GlobalID,
-					Time:     e.Time,
-					Type:     e.Type,
-					Data:     e.Data,
-				})
+			event, er := parseEvent(e)
+			if er != nil {
+				decodeErrors = append(decodeErrors, er)
 			}
+			parsedEvents = append(parsedEvents, event)
 		}
 
-		return FetchedEventsMsg{events: parsedEvents, since: since, err: err}
+		return FetchedEventsMsg{events: parsedEvents, since: since, decodeErrors: decodeErrors}
+	}
+}
+
+// parseEvent decodes the data of the event types the model handles, others keep
+// the raw data.
+func parseEvent(e syncthing.Event[json.RawMessage]) (syncthing.Event[any], error) {
+	var data any
+	var err error
+	switch e.Type {
+	case "FolderSummary":
+		data, err = decodeEventData[syncthing.FolderSummaryEventData](e.Data)
+	case "ConfigSaved":
+		data, err = decodeEventData[syncthing.Config](e.Data)
+		if err != nil {
+			// a partial config would drop folders and devices from the view
+			data = e.Data
+		}
+	case "FolderScanProgress":
+		data, err = decodeEventData[syncthing.FolderScanProgressEventData](e.Data)
+	case "StateChanged":
+		data, err = decodeEventData[syncthing.StateChangedEventData](e.Data)
+	case "FolderCompletion":
+		data, err = decodeEventData[syncthing.FolderCompletionEventData](e.Data)
+	case "PendingDevicesChanged":
+		data, err = decodeEventData[syncthing.PendingDevicesChangedEventData](e.Data)
+	case "PendingFoldersChanged":
+		data, err = decodeEventData[syncthing.PendingFoldersChangedEventData](e.Data)
+	case "DeviceConnected":
+		data, err = decodeEventData[syncthing.DeviceConnectedEventData](e.Data)
+	case "DeviceDisconnected":
+		data, err = decodeEventData[syncthing.DeviceDisconnectedEventData](e.Data)
+	case "FolderWatchStateChanged":
+		data, err = decodeEventData[syncthing.FolderWatchStateChangedEventData](e.Data)
+	case "ItemStarted":
+		data, err = decodeEventData[syncthing.ItemStartedEventData](e.Data)
+	case "ItemFinished":
+		data, err = decodeEventData[syncthing.ItemFinishedEventData](e.Data)
+	default:
+		data = e.Data
+	}
+	if err != nil {
+		err = fmt.Errorf("event %d %s: %w", e.ID, e.Type, err)
 	}
+
+	return syncthing.Event[any]{
+		ID:       e.ID,
+		GlobalID: e.GlobalID,
+		Time:     e.Time,
+		Type:     e.Type,
+		Data:     data,
+	}, err
+}
+
+// eventsDecodeError sums up the events that couldn't be fully decoded, they are
+// still listed with what could be read.
+func eventsDecodeError(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+
+	return fmt.Errorf("%w (and %d more undecodable events)", errs[0], len(errs)-1)
+}
+
+// decodeEventData decodes what it can of the event data. Fields that don't match
+// the expected types are left empty, while null or malformed data keeps the raw
+// message so the event is still listed but otherwise ignored.
+func decodeEventData[T any](raw json.RawMessage) (any, error) {
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return raw, nil
+	}
+
+	var data T
+	err := json.Unmarshal(raw, &data)
+	var typeErr *json.UnmarshalTypeError
+	if err != nil && !errors.As(err, &typeErr) {
+		return raw, err
+	}
+
+	return data, err
 }
 
 func fetchSystemStatus(httpData HttpData) tea.Cmd {
@@ -236,7 +254,7 @@ func fetchConfig(httpData HttpData) tea.Cmd {
 func fetchFolderStats(httpData HttpData) tea.Cmd {
 	return func() tea.Msg {
 		var folderStats map[string]syncthing.FolderStats
-		err := fetchBytes(httpData, *httpData.url.JoinPath(STATS_FOLDER), &folderStats)
+		err := fetchOptionalBytes(httpData, *httpData.url.JoinPath(STATS_FOLDER), &folderStats)
 		if err != nil {
 			return FetchedFolderStats{err: err}
 		}
@@ -248,7 +266,7 @@ func fetchFolderStats(httpData HttpData) tea.Cmd {
 func fetchDeviceStats(httpData HttpData) tea.Cmd {
 	return func() tea.Msg {
 		var deviceStats map[string]syncthing.DeviceStats
-		err := fetchBytes(httpData, *httpData.url.JoinPath(STATS_DEVICE), &deviceStats)
+		err := fetchOptionalBytes(httpData, *httpData.url.JoinPath(STATS_DEVICE), &deviceStats)
 		if err != nil {
 			return FetchedDeviceStats{err: err}
 		}
@@ -273,7 +291,7 @@ func fetchCompletion(httpData HttpData, deviceID, folderID string) tea.Cmd {
 			}
 		}
 
-		req.Header.Set("X-API-Key", httpData.apiKey)
+		httpData.authorize(req)
 		resp, err := httpData.client.Do(req)
 		if err != nil {
 			return FetchedCompletion{
@@ -300,6 +318,15 @@ func fetchCompletion(httpData HttpData, deviceID, folderID string) tea.Cmd {
 			}
 		}
 
+		// syncthing may answer with nothing while the folder is still being set up,
+		// which is no completion data just like the 404
+		if emptyBody(body) {
+			return FetchedCompletion{
+				deviceID: deviceID,
+				folderID: folderID,
+			}
+		}
+
 		var deviceCompletion syncthing.StatusCompletion
 		err = json.Unmarshal(body, &deviceCompletion)
 		if err != nil {
@@ -320,28 +347,38 @@ func fetchCompletion(httpData HttpData, deviceID, folderID string) tea.Cmd {
 	}
 }
 
-func postScan(httpData HttpData, folderId string) tea.Cmd {
+// postScan rescans the folder, or only the sub path inside it when not empty.
+func postScan(httpData HttpData, folderId, sub string) tea.Cmd {
 	return func() tea.Msg {
 		params := url.Values{}
 		params.Add("folder", folderId)
+		if sub != "" {
+			params.Add("sub", sub)
+		}
 		url := httpData.url.JoinPath(DB_SCAN)
 		url.RawQuery = params.Encode()
 		req, err := http.NewRequest(http.MethodPost, url.String(), nil)
 		if err != nil {
-			return nil
+			return UserPostPutEndedMsg{err: fmt.Errorf("failed folder scan request: %w", err)}
 		}
 
-		req.Header.Set("X-API-Key", httpData.apiKey)
+		httpData.authorize(req)
 		resp, err := httpData.client.Do(req)
 		if err != nil {
-			return nil
+			return UserPostPutEndedMsg{err: fmt.Errorf("failed folder scan request: %w", err)}
 		}
 		defer resp.Body.Close()
 
-		if resp.StatusCode == http.StatusNotFound {
-			return nil
+		// syncthing refuses sub paths outside of the folder
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return UserPostPutEndedMsg{err: fmt.Errorf(
+				"postScan \"%s\" failed. Got status code %d",
+				path.Join(folderId, sub),
+				resp.StatusCode,
+			)}
 		}
 
+		// the scan shows up in the folder state, there's no user action to end
 		return nil
 	}
 }
@@ -362,7 +399,7 @@ func PostDeviceConfig(httpData HttpData, device syncthing.DeviceConfig) tea.Cmd
 			}
 		}
 
-		req.Header.Set("X-API-Key", httpData.apiKey)
+		httpData.authorize(req)
 		resp, err := httpData.client.Do(req)
 		if err != nil {
 			return UserPostPutEndedMsg{
@@ -382,6 +419,37 @@ func PostDeviceConfig(httpData HttpData, device syncthing.DeviceConfig) tea.Cmd
 	}
 }
 
+// putFolder adds the folder to the config. Fields left out take the folder defaults
+// of syncthing.
+func putFolder(httpData HttpData, folder NewFolder) tea.Cmd {
+	return func() tea.Msg {
+		folderData, err := json.Marshal(folder)
+		if err != nil {
+			return UserPostPutEndedMsg{err: fmt.Errorf("error marshalling JSON: %w", err)}
+		}
+		url := httpData.url.JoinPath(CONFIG_FOLDERS)
+		req, err := http.NewRequest(http.MethodPost, url.String(), bytes.NewBuffer(folderData))
+		if err != nil {
+			return UserPostPutEndedMsg{err: err}
+		}
+
+		httpData.authorize(req)
+		resp, err := httpData.client.Do(req)
+		if err != nil {
+			return UserPostPutEndedMsg{err: err}
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return UserPostPutEndedMsg{
+				err: fmt.Errorf("adding folder %s failed with status %s", folder.ID, resp.Status),
+			}
+		}
+
+		return UserPostPutEndedMsg{action: "putFolder: " + folder.ID}
+	}
+}
+
 type (
 	ChangeConfig func(config syncthing.Config) syncthing.Config
 	PutConfig    func(httpData HttpData, foo ChangeConfig) tea.Cmd
@@ -406,7 +474,7 @@ func createPutConfig(config syncthing.Config) PutConfig {
 				return err
 			}
 
-			req.Header.Set("X-API-Key", httpData.apiKey)
+			httpData.authorize(req)
 			req.Header.Set("Content-Type", "application/json")
 			resp, err := httpData.client.Do(req)
 			if err != nil {
@@ -419,6 +487,29 @@ func createPutConfig(config syncthing.Config) PutConfig {
 	}
 }
 
+func openBrowser(target string) tea.Cmd {
+	return func() tea.Msg {
+		var cmd *exec.Cmd
+		switch runtime.GOOS {
+		case "darwin":
+			cmd = exec.Command("open", target)
+		case "windows":
+			cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", target)
+		default:
+			cmd = exec.Command("xdg-open", target)
+		}
+
+		if err := cmd.Start(); err != nil {
+			return errMsg(fmt.Errorf("failed to open browser: %w", err))
+		}
+
+		// dont leave a zombie process around
+		go func() { _ = cmd.Wait() }()
+
+		return nil
+	}
+}
+
 func currentTimeCmd() tea.Cmd {
 	return tea.Every(
 		REFETCH_CURRENT_TIME_INTERVAL,
@@ -426,55 +517,85 @@ func currentTimeCmd() tea.Cmd {
 	)
 }
 
+func refreshFolderStatusCmd(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg { return TickedFolderStatusRefreshMsg{} })
+}
+
+func refreshFolderStatsCmd(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg { return TickedFolderStatsRefreshMsg{} })
+}
+
 func fetchPendingDevices(httpData HttpData) tea.Cmd {
 	return func() tea.Msg {
+		var pendingDevices map[string]syncthing.PendingDeviceInfo
+		err := fetchOptionalBytes(
+			httpData,
+			*httpData.url.JoinPath(CLUSTER_PENDING_DEVICES),
+			&pendingDevices,
+		)
+		if err != nil {
+			return FetchedPendingDevices{
+				err: err,
+			}
+		}
+
+		return FetchedPendingDevices{
+			devices: pendingDevices,
+		}
+	}
+}
+
+func deletePendingDevice(httpData HttpData, deviceID string) tea.Cmd {
+	return func() tea.Msg {
+		params := url.Values{}
+		params.Add("device", deviceID)
 		url := httpData.url.JoinPath(CLUSTER_PENDING_DEVICES)
-		req, err := http.NewRequest(http.MethodGet, url.String(), nil)
+		url.RawQuery = params.Encode()
+		req, err := http.NewRequest(http.MethodDelete, url.String(), nil)
 		if err != nil {
 			return nil
 		}
 
-		req.Header.Set("X-API-Key", httpData.apiKey)
+		httpData.authorize(req)
 		resp, err := httpData.client.Do(req)
 		if err != nil {
 			return nil
 		}
 		defer resp.Body.Close()
 
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return FetchedPendingDevices{
-				err: err,
-			}
-		}
+		return nil
+	}
+}
 
-		var pendingDevices map[string]syncthing.PendingDeviceInfo
-		err = json.Unmarshal(body, &pendingDevices)
+func fetchPendingFolders(httpData HttpData) tea.Cmd {
+	return func() tea.Msg {
+		var pendingFolders map[string]syncthing.PendingFolderInfo
+		err := fetchOptionalBytes(
+			httpData,
+			*httpData.url.JoinPath(CLUSTER_PENDING_FOLDERS),
+			&pendingFolders,
+		)
 		if err != nil {
-			err = fmt.Errorf("error unmarshalling JSON: %w", err)
-			return FetchedPendingDevices{
-				err: err,
-			}
+			return FetchedPendingFolders{err: err}
 		}
 
-		return FetchedPendingDevices{
-			devices: pendingDevices,
-		}
+		return FetchedPendingFolders{folders: pendingFolders}
 	}
 }
 
-func deletePendingDevice(httpData HttpData, deviceID string) tea.Cmd {
+func deletePendingFolder(httpData HttpData, folderID, deviceID string) tea.Cmd {
 	return func() tea.Msg {
 		params := url.Values{}
+		params.Add("folder", folderID)
 		params.Add("device", deviceID)
-		url := httpData.url.JoinPath(CLUSTER_PENDING_DEVICES)
+		url := httpData.url.JoinPath(CLUSTER_PENDING_FOLDERS)
 		url.RawQuery = params.Encode()
 		req, err := http.NewRequest(http.MethodDelete, url.String(), nil)
 		if err != nil {
 			return nil
 		}
 
-		req.Header.Set("X-API-Key", httpData.apiKey)
+		httpData.authorize(req)
 		resp, err := httpData.client.Do(req)
 		if err != nil {
 			return nil
@@ -496,7 +617,7 @@ func postRevertChanges(httpData HttpData, folderID string) tea.Cmd {
 			return nil
 		}
 
-		req.Header.Set("X-API-Key", httpData.apiKey)
+		httpData.authorize(req)
 		resp, err := httpData.client.Do(req)
 		if err != nil {
 			return nil
@@ -507,6 +628,37 @@ func postRevertChanges(httpData HttpData, folderID string) tea.Cmd {
 	}
 }
 
+func postOverrideChanges(httpData HttpData, folderID string) tea.Cmd {
+	return func() tea.Msg {
+		params := url.Values{}
+		params.Add("folder", folderID)
+		url := httpData.url.JoinPath(DB_OVERRIDE)
+		url.RawQuery = params.Encode()
+		req, err := http.NewRequest(http.MethodPost, url.String(), nil)
+		if err != nil {
+			return UserPostPutEndedMsg{err: fmt.Errorf("failed folder override request: %w", err)}
+		}
+
+		httpData.authorize(req)
+		resp, err := httpData.client.Do(req)
+		if err != nil {
+			return UserPostPutEndedMsg{err: fmt.Errorf("failed folder override request: %w", err)}
+		}
+		defer resp.Body.Close()
+
+		// syncthing refuses folders that aren't send only
+		if resp.StatusCode != http.StatusOK {
+			return UserPostPutEndedMsg{err: fmt.Errorf(
+				"postOverrideChanges \"%s\" failed. Got status code %d",
+				folderID,
+				resp.StatusCode,
+			)}
+		}
+
+		return UserPostPutEndedMsg{action: "postOverrideChanges: " + folderID}
+	}
+}
+
 func updateFolderPause(httpData HttpData, folderID string, paused bool) tea.Cmd {
 	return func() tea.Msg {
 		type PatchData struct {
@@ -514,7 +666,172 @@ func updateFolderPause(httpData HttpData, folderID string, paused bool) tea.Cmd
 		}
 		err := patchFolder(httpData, folderID, PatchData{paused})
 
-		return UserPostPutEndedMsg{err: err, action: "updateFolderPause: " + folderID}
+		return UserPostPutEndedMsg{
+			err:      err,
+			action:   "updateFolderPause: " + folderID,
+			folderID: folderID,
+		}
+	}
+}
+
+func updateFolderIgnorePerms(httpData HttpData, folderID string, ignore bool) tea.Cmd {
+	return func() tea.Msg {
+		type PatchData struct {
+			IgnorePerms bool `json:"ignorePerms"`
+		}
+		err := patchFolder(httpData, folderID, PatchData{ignore})
+
+		return UserPostPutEndedMsg{err: err, action: "updateFolderIgnorePerms: " + folderID}
+	}
+}
+
+func updateFolderLabel(httpData HttpData, folderID string, label string) tea.Cmd {
+	return func() tea.Msg {
+		type PatchData struct {
+			Label string `json:"label"`
+		}
+		err := patchFolder(httpData, folderID, PatchData{label})
+
+		return UserPostPutEndedMsg{err: err, action: "updateFolderLabel: " + folderID}
+	}
+}
+
+func updateFolderAdvanced(
+	httpData HttpData,
+	folderID string,
+	values AdvancedFolderValues,
+) tea.Cmd {
+	return func() tea.Msg {
+		err := patchFolder(httpData, folderID, values)
+
+		return UserPostPutEndedMsg{err: err, action: "updateFolderAdvanced: " + folderID}
+	}
+}
+
+func updateFolderRescan(httpData HttpData, folderID string, intervalS int, watcher bool) tea.Cmd {
+	return func() tea.Msg {
+		type PatchData struct {
+			RescanIntervalS  int  `json:"rescanIntervalS"`
+			FsWatcherEnabled bool `json:"fsWatcherEnabled"`
+		}
+		err := patchFolder(httpData, folderID, PatchData{intervalS, watcher})
+
+		return UserPostPutEndedMsg{err: err, action: "updateFolderRescan: " + folderID}
+	}
+}
+
+// updateFolderEncryptionPasswords replaces the devices of the folder, syncthing
+// keeps the encryption passwords on them.
+func updateFolderEncryptionPasswords(
+	httpData HttpData,
+	folderID string,
+	devices []syncthing.FolderDevice,
+) tea.Cmd {
+	return func() tea.Msg {
+		type PatchData struct {
+			Devices []syncthing.FolderDevice `json:"devices"`
+		}
+		err := patchFolder(httpData, folderID, PatchData{devices})
+
+		return SavedEncryptionPasswordsMsg{folderID: folderID, err: err}
+	}
+}
+
+func updateDeviceUntrusted(httpData HttpData, deviceID string, untrusted bool) tea.Cmd {
+	return func() tea.Msg {
+		type PatchData struct {
+			Untrusted bool `json:"untrusted"`
+		}
+		err := patchDevice(httpData, deviceID, PatchData{untrusted})
+
+		return UserPostPutEndedMsg{err: err, action: "updateDeviceUntrusted: " + deviceID}
+	}
+}
+
+func deleteDevice(httpData HttpData, deviceID string) tea.Cmd {
+	return func() tea.Msg {
+		url := httpData.url.JoinPath(CONFIG_DEVICES)
+		url = url.JoinPath(deviceID)
+		req, err := http.NewRequest(http.MethodDelete, url.String(), nil)
+		if err != nil {
+			return UserPostPutEndedMsg{err: fmt.Errorf("failed device delete request: %w", err)}
+		}
+
+		httpData.authorize(req)
+		resp, err := httpData.client.Do(req)
+		if err != nil {
+			return UserPostPutEndedMsg{err: fmt.Errorf("failed device delete request: %w", err)}
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return UserPostPutEndedMsg{err: fmt.Errorf(
+				"deleteDevice \"%s\" failed. Got status code %d",
+				deviceID,
+				resp.StatusCode,
+			)}
+		}
+
+		return UserPostPutEndedMsg{action: "deleteDevice: " + deviceID}
+	}
+}
+
+func patchDevice(httpData HttpData, deviceID string, patchData any) error {
+	json, err := json.Marshal(patchData)
+	if err != nil {
+		return fmt.Errorf("error marshalling JSON: %w", err)
+	}
+
+	url := httpData.url.JoinPath(CONFIG_DEVICES)
+	url = url.JoinPath(deviceID)
+	req, err := http.NewRequest(http.MethodPatch, url.String(), bytes.NewBuffer(json))
+	if err != nil {
+		return fmt.Errorf("failed device patch request: %w", err)
+	}
+
+	httpData.authorize(req)
+	resp, err := httpData.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed device patch request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf(
+			"patchDevice \"%s\" failed. Got status code %d",
+			deviceID,
+			resp.StatusCode,
+		)
+	}
+
+	return nil
+}
+
+func deleteFolder(httpData HttpData, folderID string) tea.Cmd {
+	return func() tea.Msg {
+		url := httpData.url.JoinPath(CONFIG_FOLDERS)
+		url = url.JoinPath(folderID)
+		req, err := http.NewRequest(http.MethodDelete, url.String(), nil)
+		if err != nil {
+			return UserPostPutEndedMsg{err: fmt.Errorf("failed folder delete request: %w", err)}
+		}
+
+		httpData.authorize(req)
+		resp, err := httpData.client.Do(req)
+		if err != nil {
+			return UserPostPutEndedMsg{err: fmt.Errorf("failed folder delete request: %w", err)}
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return UserPostPutEndedMsg{err: fmt.Errorf(
+				"deleteFolder \"%s\" failed. Got status code %d",
+				folderID,
+				resp.StatusCode,
+			)}
+		}
+
+		return UserPostPutEndedMsg{action: "deleteFolder: " + folderID}
 	}
 }
 
@@ -531,7 +848,7 @@ func patchFolder(httpData HttpData, folderID string, patchData any) error {
 		return fmt.Errorf("failed folder patch request: %w", err)
 	}
 
-	req.Header.Set("X-API-Key", httpData.apiKey)
+	httpData.authorize(req)
 	resp, err := httpData.client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed folder patch request: %w", err)
@@ -556,18 +873,31 @@ func fetchBytes(httpData HttpData, url url.URL, bodyType any) error {
 		return err
 	}
 
-	req.Header.Set("X-API-Key", httpData.apiKey)
+	httpData.authorize(req)
 	resp, err := httpData.client.Do(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("GET %s failed: %w", url.Path, errNotFound)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s failed: %s", url.Path, resp.Status)
+	}
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return err
 	}
 
+	// an empty answer leaves bodyType as it is instead of failing to unmarshal
+	if emptyBody(body) {
+		return nil
+	}
+
 	err = json.Unmarshal(body, &bodyType)
 	if err != nil {
 		return fmt.Errorf("error unmarshalling JSON: %w", err)
@@ -575,3 +905,19 @@ func fetchBytes(httpData HttpData, url url.URL, bodyType any) error {
 
 	return nil
 }
+
+// fetchOptionalBytes is fetchBytes for the endpoints minimal or older syncthing
+// builds may lack, pending devices and folders, stats and paths. Core endpoints
+// keep their 404 an error, which is retried like any other.
+func fetchOptionalBytes(httpData HttpData, url url.URL, bodyType any) error {
+	err := fetchBytes(httpData, url, bodyType)
+	if errors.Is(err, errNotFound) {
+		return ErrEndpointUnavailable
+	}
+
+	return err
+}
+
+func emptyBody(body []byte) bool {
+	return len(bytes.TrimSpace(body)) == 0
+}
@@ -0,0 +1,322 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pdrolopes/syncthing_TUI/syncthing"
+)
+
+func TestParseEvent(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventType string
+		data      string
+		want      any
+		wantErr   bool
+	}{
+		{
+			name:      "state changed",
+			eventType: "StateChanged",
+			data:      `{"folder": "default", "from": "idle", "to": "scanning"}`,
+			want: syncthing.StateChangedEventData{
+				Folder: "default",
+				From:   "idle",
+				To:     "scanning",
+			},
+		},
+		{
+			name:      "null data",
+			eventType: "StateChanged",
+			data:      `null`,
+			want:      json.RawMessage(`null`),
+		},
+		{
+			name:      "missing data",
+			eventType: "StateChanged",
+			want:      json.RawMessage{},
+		},
+		{
+			name:      "mistyped field",
+			eventType: "StateChanged",
+			data:      `{"folder": 5, "from": "idle", "to": "scanning"}`,
+			want:      syncthing.StateChangedEventData{From: "idle", To: "scanning"},
+			wantErr:   true,
+		},
+		{
+			name:      "not an object",
+			eventType: "StateChanged",
+			data:      `[1, 2]`,
+			want:      syncthing.StateChangedEventData{},
+			wantErr:   true,
+		},
+		{
+			name:      "truncated",
+			eventType: "StateChanged",
+			data:      `{"folder": "def`,
+			want:      json.RawMessage(`{"folder": "def`),
+			wantErr:   true,
+		},
+		{
+			name:      "partial config",
+			eventType: "ConfigSaved",
+			data:      `{"folders": "none"}`,
+			want:      json.RawMessage(`{"folders": "none"}`),
+			wantErr:   true,
+		},
+		{
+			name:      "unknown type",
+			eventType: "LocalIndexUpdated",
+			data:      `{"items": 3}`,
+			want:      json.RawMessage(`{"items": 3}`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := parseEvent(syncthing.Event[json.RawMessage]{
+				ID:   42,
+				Type: tt.eventType,
+				Data: json.RawMessage(tt.data),
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseEvent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "event 42 "+tt.eventType) {
+				t.Errorf("parseEvent() error = %q, want the event id and type", err)
+			}
+			if event.ID != 42 || event.Type != tt.eventType {
+				t.Errorf("parseEvent() = %+v, want the event kept", event)
+			}
+			if !reflect.DeepEqual(event.Data, tt.want) {
+				t.Errorf("parseEvent() data = %#v, want %#v", event.Data, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventsDecodeError(t *testing.T) {
+	first := errors.New("event 1 StateChanged: bad")
+	tests := []struct {
+		name string
+		errs []error
+		want string
+	}{
+		{name: "none", errs: nil, want: ""},
+		{name: "one", errs: []error{first}, want: "event 1 StateChanged: bad"},
+		{
+			name: "many",
+			errs: []error{first, errors.New("event 2"), errors.New("event 3")},
+			want: "event 1 StateChanged: bad (and 2 more undecodable events)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := eventsDecodeError(tt.errs)
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.want {
+				t.Errorf("eventsDecodeError() = %q, want %q", got, tt.want)
+			}
+			if err != nil && !errors.Is(err, first) {
+				t.Errorf("eventsDecodeError() doesn't wrap the first error")
+			}
+		})
+	}
+}
+
+// testHttpData points the client at a server answering every request with status
+// and body.
+func testHttpData(t *testing.T, status int, body string) HttpData {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return HttpData{client: *server.Client(), url: *serverURL}
+}
+
+func TestFetchBytes(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    syncthing.SystemVersion
+		wantErr error
+	}{
+		{name: "empty body", status: http.StatusOK, body: ""},
+		{name: "whitespace body", status: http.StatusOK, body: " \n"},
+		{
+			name:   "version",
+			status: http.StatusOK,
+			body:   `{"version": "v1.29.0"}`,
+			want:   syncthing.SystemVersion{Version: "v1.29.0"},
+		},
+		{name: "not found", status: http.StatusNotFound, wantErr: errNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpData := testHttpData(t, tt.status, tt.body)
+			var version syncthing.SystemVersion
+			err := fetchBytes(httpData, *httpData.url.JoinPath(SYSTEM_VERSION), &version)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("fetchBytes() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(version, tt.want) {
+				t.Errorf("fetchBytes() = %+v, want %+v", version, tt.want)
+			}
+		})
+	}
+
+	t.Run("truncated body", func(t *testing.T) {
+		httpData := testHttpData(t, http.StatusOK, `{"version": `)
+		var version syncthing.SystemVersion
+		err := fetchBytes(httpData, *httpData.url.JoinPath(SYSTEM_VERSION), &version)
+		if err == nil {
+			t.Error("fetchBytes() error = nil, want the unmarshal error")
+		}
+	})
+}
+
+func TestFetchOptionalBytes(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr error
+	}{
+		{name: "found", status: http.StatusOK},
+		{name: "not found", status: http.StatusNotFound, wantErr: ErrEndpointUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpData := testHttpData(t, tt.status, `{}`)
+			var stats map[string]syncthing.DeviceStats
+			err := fetchOptionalBytes(httpData, *httpData.url.JoinPath(STATS_DEVICE), &stats)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("fetchOptionalBytes() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("server error", func(t *testing.T) {
+		httpData := testHttpData(t, http.StatusInternalServerError, "")
+		var stats map[string]syncthing.DeviceStats
+		err := fetchOptionalBytes(httpData, *httpData.url.JoinPath(STATS_DEVICE), &stats)
+		if err == nil || errors.Is(err, ErrEndpointUnavailable) {
+			t.Errorf("fetchOptionalBytes() error = %v, want a retried error", err)
+		}
+	})
+}
+
+func TestFetchCompletion(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         int
+		body           string
+		wantCompletion bool
+		wantErr        bool
+	}{
+		{name: "empty body", status: http.StatusOK, body: ""},
+		{name: "not found", status: http.StatusNotFound},
+		{
+			name:           "completion",
+			status:         http.StatusOK,
+			body:           `{"completion": 100}`,
+			wantCompletion: true,
+		},
+		{name: "truncated body", status: http.StatusOK, body: `{"completion": `, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpData := testHttpData(t, tt.status, tt.body)
+			msg := fetchCompletion(httpData, "device", "folder")().(FetchedCompletion)
+			if (msg.err != nil) != tt.wantErr {
+				t.Fatalf("fetchCompletion() error = %v, wantErr %v", msg.err, tt.wantErr)
+			}
+			if msg.hasCompletion != tt.wantCompletion {
+				t.Errorf("fetchCompletion() hasCompletion = %v, want %v",
+					msg.hasCompletion, tt.wantCompletion)
+			}
+			if msg.deviceID != "device" || msg.folderID != "folder" {
+				t.Errorf("fetchCompletion() = %+v, want the device and folder kept", msg)
+			}
+		})
+	}
+}
+
+func TestPostOverrideChanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{name: "overridden", status: http.StatusOK},
+		{name: "not send only", status: http.StatusInternalServerError, wantErr: true},
+		{name: "forbidden", status: http.StatusForbidden, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpData := testHttpData(t, tt.status, "")
+			msg, ok := postOverrideChanges(httpData, "default")().(UserPostPutEndedMsg)
+			if !ok {
+				t.Fatalf("postOverrideChanges() didn't end the user action")
+			}
+			if (msg.err != nil) != tt.wantErr {
+				t.Errorf("postOverrideChanges() error = %v, wantErr %v", msg.err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("unreachable", func(t *testing.T) {
+		httpData := testHttpData(t, http.StatusOK, "")
+		httpData.url = url.URL{Scheme: "http", Host: "127.0.0.1:1"}
+		msg := postOverrideChanges(httpData, "default")().(UserPostPutEndedMsg)
+		if msg.err == nil {
+			t.Error("postOverrideChanges() error = nil, want the request error")
+		}
+	})
+}
+
+func TestPostScan(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		sub     string
+		wantErr bool
+	}{
+		{name: "folder", status: http.StatusOK},
+		{name: "sub path", status: http.StatusOK, sub: "photos/2024"},
+		{name: "bad sub path", status: http.StatusInternalServerError, sub: "../x", wantErr: true},
+		{name: "unknown folder", status: http.StatusNotFound, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpData := testHttpData(t, tt.status, "")
+			msg := postScan(httpData, "default", tt.sub)()
+			ended, failed := msg.(UserPostPutEndedMsg)
+			if failed != tt.wantErr || (failed && ended.err == nil) {
+				t.Errorf("postScan() = %#v, wantErr %v", msg, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package app
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+	"github.com/pdrolopes/syncthing_TUI/syncthing"
+)
+
+const (
+	// conflict files kept per folder, the count still includes every one of them
+	CONFLICT_LIST_LIMIT = 100
+	CONFLICTS_VISIBLE   = 15
+	CONFLICT_MARKER     = ".sync-conflict-"
+	// scans finishing in a burst, like the watcher rescanning one subdirectory
+	// after another, walk the folder once
+	CONFLICT_SCAN_DEBOUNCE = 5 * time.Second
+)
+
+// name.sync-conflict-20240102-150405-DEVICEID.ext
+var conflictTimeRegexp = regexp.MustCompile(`\.sync-conflict-(\d{8}-\d{6})-`)
+
+type ConflictFile struct {
+	Path string
+	Time time.Time
+}
+
+type FolderConflicts struct {
+	Count int
+	// newest first, at most CONFLICT_LIST_LIMIT
+	Files []ConflictFile
+}
+
+// conflictScan tracks the walks of a folder, at most one runs at a time.
+type conflictScan struct {
+	// bumped by every finished syncthing scan, only the timer of the last one walks
+	generation int
+	running    bool
+	// a syncthing scan finished during the walk, walk again after it
+	pending bool
+}
+
+type ConflictScanDueMsg struct {
+	folderID   string
+	generation int
+}
+
+type ScannedFolderConflictsMsg struct {
+	folderID  string
+	conflicts FolderConflicts
+}
+
+// scanFolderConflicts looks for syncthing conflict copies inside the folder.
+func scanFolderConflicts(folder syncthing.FolderConfig) tea.Cmd {
+	return func() tea.Msg {
+		return ScannedFolderConflictsMsg{
+			folderID:  folder.ID,
+			conflicts: findConflicts(expandHome(folder.Path)),
+		}
+	}
+}
+
+// scanNewFolderConflicts walks the folders never walked before, every folder
+// once the config is first loaded.
+func (m model) scanNewFolderConflicts(folders []syncthing.FolderConfig) tea.Cmd {
+	cmds := make([]tea.Cmd, 0, len(folders))
+	for _, folder := range folders {
+		if _, walked := m.conflictScans[folder.ID]; !walked {
+			cmds = append(cmds, m.startConflictScan(folder))
+		}
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// debounceConflictScan walks the folder once its scans settle, new conflict copies
+// show up in a syncthing scan.
+func (m model) debounceConflictScan(folderID string) tea.Cmd {
+	if !isLocalURL(m.httpData.url) {
+		return nil
+	}
+
+	scan := m.conflictScans[folderID]
+	scan.generation++
+	m.conflictScans[folderID] = scan
+
+	generation := scan.generation
+	return tea.Tick(CONFLICT_SCAN_DEBOUNCE, func(time.Time) tea.Msg {
+		return ConflictScanDueMsg{folderID: folderID, generation: generation}
+	})
+}
+
+// startConflictScan walks the folder unless a walk is already running, that one
+// is followed by another. The REST API doesn't report conflict copies, so it only
+// works when syncthing runs on this machine.
+func (m model) startConflictScan(folder syncthing.FolderConfig) tea.Cmd {
+	if !isLocalURL(m.httpData.url) {
+		return nil
+	}
+
+	scan := m.conflictScans[folder.ID]
+	var cmd tea.Cmd
+	switch {
+	case scan.running:
+		scan.pending = true
+	case !folder.Paused:
+		scan.running = true
+		cmd = scanFolderConflicts(folder)
+	}
+	m.conflictScans[folder.ID] = scan
+
+	return cmd
+}
+
+func findConflicts(root string) FolderConflicts {
+	var conflicts FolderConflicts
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// unreadable entries are skipped, a missing root ends the walk
+			if path == root {
+				return fs.SkipAll
+			}
+			return nil
+		}
+		if d.IsDir() && (d.Name() == ".stversions" || d.Name() == ".stfolder") {
+			return fs.SkipDir
+		}
+		if d.IsDir() || !strings.Contains(d.Name(), CONFLICT_MARKER) {
+			return nil
+		}
+
+		conflicts.Count++
+		conflicts.Files = append(conflicts.Files, ConflictFile{
+			Path: path,
+			Time: conflictTime(d),
+		})
+		return nil
+	})
+
+	sort.Slice(conflicts.Files, func(i, j int) bool {
+		return conflicts.Files[i].Time.After(conflicts.Files[j].Time)
+	})
+	if len(conflicts.Files) > CONFLICT_LIST_LIMIT {
+		conflicts.Files = conflicts.Files[:CONFLICT_LIST_LIMIT]
+	}
+
+	return conflicts
+}
+
+// conflictTime reads the time syncthing encodes in the file name, falling back to
+// the modification time.
+func conflictTime(d fs.DirEntry) time.Time {
+	if match := conflictTimeRegexp.FindStringSubmatch(d.Name()); match != nil {
+		if t, err := time.ParseInLocation("20060102-150405", match[1], time.Local); err == nil {
+			return t
+		}
+	}
+
+	info, err := d.Info()
+	if err != nil {
+		return time.Time{}
+	}
+
+	return info.ModTime()
+}
+
+type ConflictsModel struct {
+	Show        bool
+	folderLabel string
+	folderPath  string
+	conflicts   FolderConflicts
+	cursor      int
+	zonePrefix  string
+}
+
+func NewConflicts(folder FolderViewModel) ConflictsModel {
+	return ConflictsModel{
+		Show:        true,
+		folderLabel: folderName(folder),
+		folderPath:  expandHome(folder.Config.Path),
+		conflicts:   folder.Conflicts,
+		zonePrefix:  zone.NewPrefix(),
+	}
+}
+
+func (m ConflictsModel) rowMark(i int) string {
+	return fmt.Sprintf("%srow/%d", m.zonePrefix, i)
+}
+
+// openSelected shows the directory of the selected conflict in the file manager.
+func (m ConflictsModel) openSelected() tea.Cmd {
+	if m.cursor >= len(m.conflicts.Files) {
+		return nil
+	}
+
+	return openBrowser(filepath.Dir(m.conflicts.Files[m.cursor].Path))
+}
+
+func (m ConflictsModel) Update(msg tea.Msg) (ConflictsModel, tea.Cmd) {
+	if !m.Show {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q", "C":
+			m.Show = false
+		case "up", "k":
+			m.cursor = max(m.cursor-1, 0)
+		case "down", "j":
+			m.cursor = max(min(m.cursor+1, len(m.conflicts.Files)-1), 0)
+		case "enter", "o":
+			return m, m.openSelected()
+		}
+	case tea.MouseMsg:
+		if msg.Action != tea.MouseActionRelease || msg.Button != tea.MouseButtonLeft {
+			return m, nil
+		}
+
+		if zone.Get(m.zonePrefix + "close").InBounds(msg) {
+			m.Show = false
+			return m, nil
+		}
+
+		for i := range m.conflicts.Files {
+			if zone.Get(m.rowMark(i)).InBounds(msg) {
+				m.cursor = i
+				return m, m.openSelected()
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m ConflictsModel) View(currentTime time.Time, timeStyle TimeStyle) string {
+	const width = 80
+	header := lipgloss.NewStyle().
+		Padding(0, 1).
+		Width(width).
+		Background(styles.WarningColor).
+		Render(fmt.Sprintf("Conflicts: %s (%d)", m.folderLabel, m.conflicts.Count))
+
+	rows := make([]string, 0, CONFLICTS_VISIBLE)
+	start := max(0, m.cursor-CONFLICTS_VISIBLE+1)
+	for i := start; i < len(m.conflicts.Files) && i < start+CONFLICTS_VISIBLE; i++ {
+		conflict := m.conflicts.Files[i]
+		name, err := filepath.Rel(m.folderPath, conflict.Path)
+		if err != nil {
+			name = conflict.Path
+		}
+		row := lipgloss.NewStyle().Width(width - 2).MaxHeight(1).Render(
+			fmt.Sprintf("%-19s  %s", FormatInstant(conflict.Time, currentTime, timeStyle), name),
+		)
+		if i == m.cursor {
+			row = lipgloss.NewStyle().Reverse(true).Render(row)
+		}
+		rows = append(rows, zone.Mark(m.rowMark(i), row))
+	}
+	if len(rows) == 0 {
+		rows = append(rows, "No conflicts")
+	}
+	if m.conflicts.Count > len(m.conflicts.Files) {
+		rows = append(rows, lipgloss.NewStyle().Italic(true).Render(
+			fmt.Sprintf("showing the newest %d", len(m.conflicts.Files)),
+		))
+	}
+
+	body := lipgloss.NewStyle().
+		Padding(1, 1).
+		Width(width).
+		Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+	hint := lipgloss.NewStyle().Padding(0, 1).Render("[enter] open location   [esc] close")
+	actions := viewFooter(width, []string{hint}, []string{
+		zone.Mark(m.zonePrefix+"close", styles.BtnStyleV2.Render("Close")),
+	})
+
+	return lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Render(
+		lipgloss.JoinVertical(lipgloss.Left, header, body, actions),
+	)
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdrolopes/syncthing_TUI/syncthing"
+)
+
+func TestConflictScans(t *testing.T) {
+	dir := t.TempDir()
+	conflict := filepath.Join(dir, "notes.sync-conflict-20240102-150405-ABCDEFG.txt")
+	if err := os.WriteFile(conflict, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	folder := syncthing.FolderConfig{ID: "default", Path: dir}
+	config := FetchedConfig{config: syncthing.Config{Folders: []syncthing.FolderConfig{folder}}}
+
+	m := NewModel(Options{URL: "http://127.0.0.1:8384"})
+	m = updateAll(m, config, config)
+	if got := m.conflictScans["default"]; !got.running || got.generation != 0 {
+		t.Fatalf("after the config = %+v, want a single walk at startup", got)
+	}
+
+	// scans finishing during the walk only queue another one, the timers of the
+	// debounce are skipped with their messages
+	m.debounceConflictScan("default")
+	first := ConflictScanDueMsg{folderID: "default", generation: 1}
+	m.debounceConflictScan("default")
+	second := ConflictScanDueMsg{folderID: "default", generation: 2}
+	updated, cmd := m.Update(first)
+	m = updated.(model)
+	if cmd != nil {
+		t.Error("the debounced scan walked, want only the last one")
+	}
+	updated, cmd = m.Update(second)
+	m = updated.(model)
+	if got := m.conflictScans["default"]; cmd != nil || !got.pending {
+		t.Errorf("while walking = %+v, want the walk queued", got)
+	}
+
+	updated, cmd = m.Update(ScannedFolderConflictsMsg{folderID: "default"})
+	m = updated.(model)
+	if cmd == nil || m.conflictScans["default"].running {
+		t.Fatalf("after the walk = %+v, want the queued one debounced", m.conflictScans["default"])
+	}
+
+	due := ConflictScanDueMsg{folderID: "default", generation: 3}
+	updated, cmd = m.Update(due)
+	m = updated.(model)
+	if cmd == nil || !m.conflictScans["default"].running {
+		t.Fatalf("queued walk didn't start, scan %+v", m.conflictScans["default"])
+	}
+	m = updateAll(m, cmd())
+	if m.folders[0].Conflicts.Count != 1 || m.folders[0].Conflicts.Files[0].Path != conflict {
+		t.Errorf("conflicts = %+v, want %s", m.folders[0].Conflicts, conflict)
+	}
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samber/lo"
+)
+
+// DeviceFilter narrows the device list by connection state, empty lists them all.
+type DeviceFilter string
+
+const (
+	DeviceFilterAll          DeviceFilter = ""
+	DeviceFilterConnected    DeviceFilter = "connected"
+	DeviceFilterDisconnected DeviceFilter = "disconnected"
+)
+
+var deviceFilterKeys = key.NewBinding(
+	key.WithKeys("D"),
+	key.WithHelp("D", "filter devices by connection state"),
+)
+
+func (f DeviceFilter) Next() DeviceFilter {
+	switch f {
+	case DeviceFilterAll:
+		return DeviceFilterConnected
+	case DeviceFilterConnected:
+		return DeviceFilterDisconnected
+	default:
+		return DeviceFilterAll
+	}
+}
+
+// Matches groups the device statuses by connection state. Paused devices count as
+// disconnected, devices without connection info yet match neither.
+func (f DeviceFilter) Matches(device DeviceViewModel, currentTime time.Time) bool {
+	switch deviceStatus(device, currentTime) {
+	case DeviceInSync, DeviceUnusedInSync, DeviceSyncing:
+		return f != DeviceFilterDisconnected
+	case DeviceUnknown:
+		return f == DeviceFilterAll
+	default:
+		return f != DeviceFilterConnected
+	}
+}
+
+func filterDevices(
+	devices []DeviceViewModel,
+	filter DeviceFilter,
+	currentTime time.Time,
+) []DeviceViewModel {
+	if filter == DeviceFilterAll {
+		return devices
+	}
+
+	return lo.Filter(devices, func(d DeviceViewModel, index int) bool {
+		return filter.Matches(d, currentTime)
+	})
+}
+
+func viewDeviceFilter(filter DeviceFilter, hidden int) string {
+	if filter == DeviceFilterAll {
+		return ""
+	}
+
+	return lipgloss.NewStyle().Italic(true).Faint(true).Padding(0, 1).Render(
+		fmt.Sprintf("only %s devices, %d hidden (D to change)", filter, hidden))
+}
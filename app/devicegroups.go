@@ -0,0 +1,147 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+	"github.com/samber/lo"
+)
+
+// group listing the devices without one
+const DEFAULT_DEVICE_GROUP = "Ungrouped"
+
+// DeviceGroupModel edits the local group of a device. Groups only live in the TUI
+// settings, syncthing config is never touched.
+type DeviceGroupModel struct {
+	Show       bool
+	deviceID   string
+	deviceName string
+	input      textinput.Model
+	zonePrefix string
+}
+
+type EditedDeviceGroupMsg struct {
+	deviceID string
+	// empty removes the device from its group
+	group string
+}
+
+func NewDeviceGroup(device DeviceViewModel, group string) DeviceGroupModel {
+	input := textinput.New()
+	input.Placeholder = DEFAULT_DEVICE_GROUP
+	input.CharLimit = 30
+	input.SetValue(group)
+	input.Focus()
+
+	return DeviceGroupModel{
+		Show:       true,
+		deviceID:   device.Config.DeviceID,
+		deviceName: device.Config.Name,
+		input:      input,
+		zonePrefix: zone.NewPrefix(),
+	}
+}
+
+func (m DeviceGroupModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m DeviceGroupModel) save() (DeviceGroupModel, tea.Cmd) {
+	m.Show = false
+	msg := EditedDeviceGroupMsg{deviceID: m.deviceID, group: strings.TrimSpace(m.input.Value())}
+
+	return m, func() tea.Msg { return msg }
+}
+
+func (m DeviceGroupModel) Update(msg tea.Msg) (DeviceGroupModel, tea.Cmd) {
+	if !m.Show {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyEsc:
+			m.Show = false
+			return m, nil
+		case tea.KeyEnter:
+			return m.save()
+		}
+	case tea.MouseMsg:
+		if msg.Action != tea.MouseActionRelease || msg.Button != tea.MouseButtonLeft {
+			return m, nil
+		}
+
+		if zone.Get(m.zonePrefix + "close").InBounds(msg) {
+			m.Show = false
+			return m, nil
+		}
+
+		if zone.Get(m.zonePrefix + "save").InBounds(msg) {
+			return m.save()
+		}
+
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m DeviceGroupModel) View() string {
+	const width = 50
+	header := lipgloss.NewStyle().
+		Padding(0, 1).
+		Width(width).
+		Background(styles.AccentColor).
+		Render(fmt.Sprintf("Group: %s", m.deviceName))
+
+	body := lipgloss.NewStyle().Padding(1, 1).Width(width).Render(
+		lipgloss.JoinVertical(lipgloss.Left,
+			m.input.View(),
+			"",
+			lipgloss.NewStyle().
+				Italic(true).
+				Render("Leave empty to remove the device from its group."),
+		),
+	)
+	actions := lipgloss.PlaceHorizontal(width, lipgloss.Right, lipgloss.JoinHorizontal(lipgloss.Top,
+		zone.Mark(m.zonePrefix+"save", styles.BtnStyleV2.Render("Save")),
+		"  ",
+		zone.Mark(m.zonePrefix+"close", styles.BtnStyleV2.Render("Close")),
+	))
+
+	return lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Render(
+		lipgloss.JoinVertical(lipgloss.Left, header, body, actions),
+	)
+}
+
+// groupDevices splits devices by their local group, sorted by group name with
+// the ungrouped devices last.
+func groupDevices(
+	devices []DeviceViewModel,
+	groups map[string]string,
+) []lo.Tuple2[string, []DeviceViewModel] {
+	byGroup := lo.GroupBy(devices, func(d DeviceViewModel) string {
+		return groups[d.Config.DeviceID]
+	})
+
+	names := lo.Without(lo.Keys(byGroup), "")
+	sort.Strings(names)
+
+	grouped := lo.Map(names, func(name string, index int) lo.Tuple2[string, []DeviceViewModel] {
+		return lo.T2(name, byGroup[name])
+	})
+	if ungrouped, has := byGroup[""]; has {
+		grouped = append(grouped, lo.T2(DEFAULT_DEVICE_GROUP, ungrouped))
+	}
+
+	return grouped
+}
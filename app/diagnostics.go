@@ -0,0 +1,64 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+	"github.com/pdrolopes/syncthing_TUI/syncthing"
+)
+
+// Diagnostics are the connection problems syncthing reports in its status.
+// Discovery errors have their own panel.
+type Diagnostics struct {
+	// listener address -> error, only the failing listeners
+	Listeners map[string]string
+	// addresses whose last dial failed, out of all the dialed ones
+	FailedDials int
+	Dials       int
+}
+
+func systemDiagnostics(status syncthing.SystemStatus) Diagnostics {
+	diagnostics := Diagnostics{Listeners: make(map[string]string)}
+	for listener, s := range status.ConnectionServiceStatus {
+		if s.Error != nil && *s.Error != "" {
+			diagnostics.Listeners[listener] = *s.Error
+		}
+	}
+	for _, dial := range status.LastDialStatus {
+		diagnostics.Dials++
+		if dial.Error != nil && *dial.Error != "" {
+			diagnostics.FailedDials++
+		}
+	}
+
+	return diagnostics
+}
+
+// viewDiagnostics lists the failing listeners. Failed dials are only counted as
+// they are expected for every offline device.
+func viewDiagnostics(diagnostics Diagnostics, width int) string {
+	if len(diagnostics.Listeners) == 0 && diagnostics.FailedDials == 0 {
+		return ""
+	}
+
+	warning := lipgloss.NewStyle().Foreground(styles.WarningColor).Width(width).MaxHeight(2)
+	listeners := make([]string, 0, len(diagnostics.Listeners))
+	for listener := range diagnostics.Listeners {
+		listeners = append(listeners, listener)
+	}
+	sort.Strings(listeners)
+
+	lines := []string{lipgloss.NewStyle().PaddingTop(1).Bold(true).Render("Diagnostics")}
+	for _, listener := range listeners {
+		lines = append(lines,
+			warning.Render(fmt.Sprintf("⚠ %s: %s", listener, diagnostics.Listeners[listener])))
+	}
+	if diagnostics.FailedDials > 0 {
+		lines = append(lines, warning.Render(fmt.Sprintf("⚠ %d of %d addresses failed to dial",
+			diagnostics.FailedDials, diagnostics.Dials)))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
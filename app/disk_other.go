@@ -0,0 +1,9 @@
+//go:build !linux && !darwin
+
+package app
+
+import "errors"
+
+func diskUsage(path string) (HomeDisk, error) {
+	return HomeDisk{}, errors.ErrUnsupported
+}
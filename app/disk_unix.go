@@ -0,0 +1,19 @@
+//go:build linux || darwin
+
+package app
+
+import "syscall"
+
+func diskUsage(path string) (HomeDisk, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return HomeDisk{}, err
+	}
+
+	// field types change between platforms and architectures
+	blockSize := uint64(stat.Bsize)
+	return HomeDisk{
+		Free:  uint64(stat.Bavail) * blockSize,
+		Total: uint64(stat.Blocks) * blockSize,
+	}, nil
+}
@@ -0,0 +1,258 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	humanize "github.com/dustin/go-humanize"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+	"github.com/pdrolopes/syncthing_TUI/syncthing"
+	"github.com/samber/lo"
+)
+
+const (
+	REFETCH_HOME_DISK_INTERVAL = time.Minute
+	// warn once free space is below this many times the minimum
+	HOME_DISK_WARNING_FACTOR = 2
+)
+
+// units accepted by syncthing for disk space options, sizes are decimal
+var diskSpaceUnits = []string{"%", "kB", "MB", "GB", "TB"}
+
+// HomeDisk is the usage of the disk holding the syncthing configuration.
+type HomeDisk struct {
+	Free  uint64
+	Total uint64
+}
+
+type FetchedHomeDiskMsg struct {
+	disk HomeDisk
+	err  error
+}
+
+// fetchHomeDisk measures the disk of the syncthing home directory. It needs
+// syncthing to run on this machine, the REST API doesn't report disk usage.
+func fetchHomeDisk(httpData HttpData) tea.Cmd {
+	if !isLocalURL(httpData.url) {
+		return nil
+	}
+
+	return func() tea.Msg {
+		var paths map[string]string
+		err := fetchOptionalBytes(httpData, *httpData.url.JoinPath(SYSTEM_PATHS), &paths)
+		if err != nil {
+			return FetchedHomeDiskMsg{err: err}
+		}
+
+		configPath, has := paths["config"]
+		if !has {
+			return FetchedHomeDiskMsg{err: errors.New("syncthing didn't report its config path")}
+		}
+
+		disk, err := diskUsage(filepath.Dir(configPath))
+		return FetchedHomeDiskMsg{disk: disk, err: err}
+	}
+}
+
+func diskSpaceBytes(space syncthing.DiskSpace, total uint64) uint64 {
+	multiplier := map[string]float64{
+		"%":  float64(total) / 100,
+		"kB": 1e3,
+		"MB": 1e6,
+		"GB": 1e9,
+		"TB": 1e12,
+	}[space.Unit]
+
+	return uint64(math.Max(space.Value, 0) * multiplier)
+}
+
+func diskSpaceLabel(space syncthing.DiskSpace) string {
+	return fmt.Sprintf("%s %s", strconv.FormatFloat(space.Value, 'f', -1, 64), space.Unit)
+}
+
+// homeDiskLabel shows the minimum free space, with the current free space when known.
+func homeDiskLabel(space syncthing.DiskSpace, disk HomeDisk) string {
+	label := diskSpaceLabel(space)
+	if disk.Total == 0 {
+		return label
+	}
+
+	label = fmt.Sprintf("%s (%s free)", label, humanize.IBytes(disk.Free))
+	if homeDiskLow(space, disk) {
+		return lipgloss.NewStyle().Foreground(styles.WarningColor).Render("⚠ " + label)
+	}
+
+	return label
+}
+
+// homeDiskLow reports when syncthing gets close to pausing the folders for lack of space.
+func homeDiskLow(space syncthing.DiskSpace, disk HomeDisk) bool {
+	return disk.Total != 0 && disk.Free < diskSpaceBytes(space, disk.Total)*HOME_DISK_WARNING_FACTOR
+}
+
+// MinHomeDiskFreeModel edits the free space syncthing keeps on its home disk
+// before pausing every folder.
+type MinHomeDiskFreeModel struct {
+	Show       bool
+	input      textinput.Model
+	unit       int
+	err        error
+	zonePrefix string
+	putConfig  PutConfig
+	httpData   HttpData
+}
+
+func NewMinHomeDiskFree(
+	space syncthing.DiskSpace,
+	putConfig PutConfig,
+	httpData HttpData,
+) MinHomeDiskFreeModel {
+	input := textinput.New()
+	input.CharLimit = 12
+	input.Width = 12
+	input.SetValue(strconv.FormatFloat(space.Value, 'f', -1, 64))
+	input.Focus()
+
+	return MinHomeDiskFreeModel{
+		Show:       true,
+		input:      input,
+		unit:       max(lo.IndexOf(diskSpaceUnits, space.Unit), 0),
+		zonePrefix: zone.NewPrefix(),
+		putConfig:  putConfig,
+		httpData:   httpData,
+	}
+}
+
+func (m MinHomeDiskFreeModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m MinHomeDiskFreeModel) unitMark(i int) string {
+	return fmt.Sprintf("%sunit/%d", m.zonePrefix, i)
+}
+
+func (m MinHomeDiskFreeModel) save() (MinHomeDiskFreeModel, tea.Cmd) {
+	value, err := strconv.ParseFloat(strings.TrimSpace(m.input.Value()), 64)
+	unit := diskSpaceUnits[m.unit]
+	switch {
+	case err != nil || value < 0:
+		m.err = errors.New("value must be a positive number")
+		return m, nil
+	case unit == "%" && value > 100:
+		m.err = errors.New("percentage can't be over 100")
+		return m, nil
+	}
+
+	m.Show = false
+	if m.putConfig == nil {
+		return m, nil
+	}
+
+	return m, m.putConfig(m.httpData, func(config syncthing.Config) syncthing.Config {
+		config.Options.MinHomeDiskFree = syncthing.DiskSpace{Value: value, Unit: unit}
+		return config
+	})
+}
+
+func (m MinHomeDiskFreeModel) Update(msg tea.Msg) (MinHomeDiskFreeModel, tea.Cmd) {
+	if !m.Show {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyEsc:
+			m.Show = false
+			return m, nil
+		case tea.KeyEnter:
+			return m.save()
+		case tea.KeyTab, tea.KeyRight:
+			m.unit = (m.unit + 1) % len(diskSpaceUnits)
+			return m, nil
+		case tea.KeyShiftTab, tea.KeyLeft:
+			m.unit = (m.unit - 1 + len(diskSpaceUnits)) % len(diskSpaceUnits)
+			return m, nil
+		}
+	case tea.MouseMsg:
+		if msg.Action != tea.MouseActionRelease || msg.Button != tea.MouseButtonLeft {
+			return m, nil
+		}
+
+		if zone.Get(m.zonePrefix + "close").InBounds(msg) {
+			m.Show = false
+			return m, nil
+		}
+
+		if zone.Get(m.zonePrefix + "save").InBounds(msg) {
+			return m.save()
+		}
+
+		for i := range diskSpaceUnits {
+			if zone.Get(m.unitMark(i)).InBounds(msg) {
+				m.unit = i
+				return m, nil
+			}
+		}
+
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m MinHomeDiskFreeModel) View() string {
+	const width = 60
+	header := lipgloss.NewStyle().
+		Padding(0, 1).
+		Width(width).
+		Background(styles.AccentColor).
+		Render("Minimum Home Disk Free Space")
+
+	units := lo.Map(diskSpaceUnits, func(unit string, i int) string {
+		style := lo.Ternary(i == m.unit, styles.BtnStyleV2, lipgloss.NewStyle().Padding(0, 1))
+		return zone.Mark(m.unitMark(i), style.Render(unit))
+	})
+
+	rows := []string{
+		lipgloss.JoinHorizontal(lipgloss.Center,
+			m.input.View(),
+			" ",
+			lipgloss.JoinHorizontal(lipgloss.Top, units...),
+		),
+		"",
+		lipgloss.NewStyle().Italic(true).Render(
+			"Syncthing pauses all folders when the disk holding its configuration " +
+				"has less free space than this. [tab] changes the unit.",
+		),
+	}
+	if m.err != nil {
+		rows = append(rows, "",
+			lipgloss.NewStyle().Foreground(styles.ErrorColor).Render(m.err.Error()))
+	}
+
+	body := lipgloss.NewStyle().
+		Padding(1, 1).
+		Width(width).
+		Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+	actions := lipgloss.PlaceHorizontal(width, lipgloss.Right, lipgloss.JoinHorizontal(lipgloss.Top,
+		zone.Mark(m.zonePrefix+"save", styles.BtnStyleV2.Render("Save")),
+		"  ",
+		zone.Mark(m.zonePrefix+"close", styles.BtnStyleV2.Render("Close")),
+	))
+
+	return lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Render(
+		lipgloss.JoinVertical(lipgloss.Left, header, body, actions),
+	)
+}
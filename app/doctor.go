@@ -0,0 +1,140 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/pdrolopes/syncthing_TUI/syncthing"
+)
+
+const DOCTOR_TIMEOUT = 10 * time.Second
+
+type doctorCheck struct {
+	name string
+	run  func(httpData HttpData) (string, error)
+}
+
+// RunDoctor checks the connection to syncthing step by step, printing the result
+// of each check to w. It returns false when any check fails.
+func RunDoctor(w io.Writer, options Options) bool {
+	httpData, err := newHttpData(options)
+	if err != nil {
+		fmt.Fprintf(w, "✗ configuration: %s\n", err)
+		return false
+	}
+	httpData.client.Timeout = DOCTOR_TIMEOUT
+
+	checks := []doctorCheck{
+		{name: "resolve host", run: doctorResolve},
+		{name: "reach host", run: doctorReach},
+		{name: "api key", run: doctorPing},
+		{name: "fetch config", run: doctorConfig},
+		{name: "events endpoint", run: doctorEvents},
+		{name: "syncthing version", run: doctorVersion},
+	}
+
+	fmt.Fprintf(w, "Checking syncthing at %s\n", httpData.url.String())
+	ok := true
+	for _, check := range checks {
+		detail, err := check.run(httpData)
+		if err != nil {
+			fmt.Fprintf(w, "✗ %s: %s\n", check.name, err)
+			ok = false
+			// the following checks depend on the previous ones
+			break
+		}
+
+		if detail != "" {
+			fmt.Fprintf(w, "✓ %s: %s\n", check.name, detail)
+		} else {
+			fmt.Fprintf(w, "✓ %s\n", check.name)
+		}
+	}
+
+	return ok
+}
+
+func hostPort(u url.URL) string {
+	if u.Port() != "" {
+		return u.Host
+	}
+	if u.Scheme == "https" {
+		return net.JoinHostPort(u.Hostname(), "443")
+	}
+
+	return net.JoinHostPort(u.Hostname(), "80")
+}
+
+func doctorResolve(httpData HttpData) (string, error) {
+	addrs, err := net.LookupHost(httpData.url.Hostname())
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprint(addrs), nil
+}
+
+func doctorReach(httpData HttpData) (string, error) {
+	conn, err := net.DialTimeout("tcp", hostPort(httpData.url), DOCTOR_TIMEOUT)
+	if err != nil {
+		return "", err
+	}
+	conn.Close()
+
+	return "", nil
+}
+
+func doctorPing(httpData HttpData) (string, error) {
+	if httpData.apiKey == "" {
+		return "", fmt.Errorf("missing api key. Env: SYNCTHING_API_KEY or flag: -api-key")
+	}
+
+	var ping struct {
+		Ping string `json:"ping"`
+	}
+	if err := fetchBytes(httpData, *httpData.url.JoinPath(SYSTEM_PING), &ping); err != nil {
+		return "", err
+	}
+	if ping.Ping != "pong" {
+		return "", fmt.Errorf("unexpected ping answer %q, is the api key valid?", ping.Ping)
+	}
+
+	return "", nil
+}
+
+func doctorConfig(httpData HttpData) (string, error) {
+	var config syncthing.Config
+	if err := fetchBytes(httpData, *httpData.url.JoinPath(CONFIG), &config); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%d folders, %d devices", len(config.Folders), len(config.Devices)), nil
+}
+
+func doctorEvents(httpData HttpData) (string, error) {
+	params := url.Values{}
+	params.Add("limit", "1")
+	params.Add("timeout", "1")
+	u := httpData.url.JoinPath(EVENTS)
+	u.RawQuery = params.Encode()
+
+	var events []syncthing.Event[json.RawMessage]
+	if err := fetchBytes(httpData, *u, &events); err != nil {
+		return "", err
+	}
+
+	return "", nil
+}
+
+func doctorVersion(httpData HttpData) (string, error) {
+	var version syncthing.SystemVersion
+	if err := fetchBytes(httpData, *httpData.url.JoinPath(SYSTEM_VERSION), &version); err != nil {
+		return "", err
+	}
+
+	return version.LongVersion, nil
+}
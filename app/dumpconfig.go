@@ -0,0 +1,70 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/pdrolopes/syncthing_TUI/syncthing"
+)
+
+const REDACTED = "REDACTED"
+
+// DumpConfig fetches the syncthing config and writes it to w as indented JSON.
+// Fields unknown to syncthing.Config are not part of the dump.
+func DumpConfig(w io.Writer, options Options, redact bool) error {
+	httpData, err := newHttpData(options)
+	if err != nil {
+		return err
+	}
+
+	msg, ok := fetchConfig(httpData)().(FetchedConfig)
+	if !ok {
+		return errors.New("unexpected response while fetching the config")
+	}
+	if msg.err != nil {
+		return fmt.Errorf("failed to fetch config: %w", msg.err)
+	}
+
+	config := msg.config
+	if redact {
+		config = redactConfig(config)
+	}
+
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshalling JSON: %w", err)
+	}
+
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// redactConfig hides the secrets of the config, leaving empty ones untouched so
+// the dump still shows which are set.
+func redactConfig(config syncthing.Config) syncthing.Config {
+	redact := func(secret string) string {
+		if secret == "" {
+			return ""
+		}
+		return REDACTED
+	}
+
+	config.GUI.APIKey = redact(config.GUI.APIKey)
+	config.GUI.Password = redact(config.GUI.Password)
+
+	folders := make([]syncthing.FolderConfig, len(config.Folders))
+	for i, folder := range config.Folders {
+		devices := make([]syncthing.FolderDevice, len(folder.Devices))
+		for j, device := range folder.Devices {
+			device.EncryptionPassword = redact(device.EncryptionPassword)
+			devices[j] = device
+		}
+		folder.Devices = devices
+		folders[i] = folder
+	}
+	config.Folders = folders
+
+	return config
+}
@@ -0,0 +1,228 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+	"github.com/pdrolopes/syncthing_TUI/syncthing"
+)
+
+// SavedEncryptionPasswordsMsg ends a save, the modal stays open when it failed.
+// A failure after the modal was closed goes to the error banner.
+type SavedEncryptionPasswordsMsg struct {
+	folderID string
+	err      error
+}
+
+// EncryptionPasswordsModel edits the per device encryption passwords of a folder.
+// Devices with a password receive the folder encrypted.
+type EncryptionPasswordsModel struct {
+	Show        bool
+	folderID    string
+	folderLabel string
+	// every device of the folder, the patch replaces them all
+	folderDevices []syncthing.FolderDevice
+	deviceIDs     []string
+	deviceNames   []string
+	inputs        []textinput.Model
+	focus         int
+	saving        bool
+	err           error
+	zonePrefix    string
+	httpData      HttpData
+}
+
+func NewEncryptionPasswords(
+	folder FolderViewModel,
+	devices []DeviceViewModel,
+	thisDeviceID string,
+	httpData HttpData,
+) EncryptionPasswordsModel {
+	m := EncryptionPasswordsModel{
+		Show:          true,
+		folderID:      folder.Config.ID,
+		folderLabel:   folderName(folder),
+		folderDevices: folder.Config.Devices,
+		zonePrefix:    zone.NewPrefix(),
+		httpData:      httpData,
+	}
+
+	for _, folderDevice := range folder.Config.Devices {
+		if folderDevice.DeviceID == thisDeviceID {
+			continue
+		}
+
+		name := shortIdentification(folderDevice.DeviceID)
+		for _, d := range devices {
+			if d.Config.DeviceID == folderDevice.DeviceID {
+				name = d.Config.Name
+			}
+		}
+
+		input := textinput.New()
+		input.EchoMode = textinput.EchoPassword
+		input.EchoCharacter = '•'
+		input.Placeholder = "not encrypted"
+		input.SetValue(folderDevice.EncryptionPassword)
+		m.deviceIDs = append(m.deviceIDs, folderDevice.DeviceID)
+		m.deviceNames = append(m.deviceNames, name)
+		m.inputs = append(m.inputs, input)
+	}
+
+	if len(m.inputs) > 0 {
+		m.inputs[0].Focus()
+	}
+
+	return m
+}
+
+func (m EncryptionPasswordsModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m EncryptionPasswordsModel) inputMark(i int) string {
+	return fmt.Sprintf("%sinput/%d", m.zonePrefix, i)
+}
+
+func (m EncryptionPasswordsModel) focusInput(i int) (EncryptionPasswordsModel, tea.Cmd) {
+	if len(m.inputs) == 0 {
+		return m, nil
+	}
+
+	m.focus = (i + len(m.inputs)) % len(m.inputs)
+	for j := range m.inputs {
+		m.inputs[j].Blur()
+	}
+
+	return m, m.inputs[m.focus].Focus()
+}
+
+// save closes the modal once syncthing took the passwords.
+func (m EncryptionPasswordsModel) save() (EncryptionPasswordsModel, tea.Cmd) {
+	if m.saving {
+		return m, nil
+	}
+
+	passwords := make(map[string]string, len(m.inputs))
+	for i, input := range m.inputs {
+		passwords[m.deviceIDs[i]] = input.Value()
+	}
+	devices := make([]syncthing.FolderDevice, len(m.folderDevices))
+	copy(devices, m.folderDevices)
+	for i, d := range devices {
+		if password, has := passwords[d.DeviceID]; has {
+			devices[i].EncryptionPassword = password
+		}
+	}
+
+	m.saving = true
+	m.err = nil
+	return m, updateFolderEncryptionPasswords(m.httpData, m.folderID, devices)
+}
+
+func (m EncryptionPasswordsModel) Update(msg tea.Msg) (EncryptionPasswordsModel, tea.Cmd) {
+	if !m.Show {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case SavedEncryptionPasswordsMsg:
+		if msg.folderID != m.folderID {
+			return m, nil
+		}
+		m.saving = false
+		m.err = msg.err
+		m.Show = msg.err != nil
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyEsc:
+			m.Show = false
+			return m, nil
+		case tea.KeyEnter:
+			return m.save()
+		case tea.KeyTab, tea.KeyDown:
+			return m.focusInput(m.focus + 1)
+		case tea.KeyShiftTab, tea.KeyUp:
+			return m.focusInput(m.focus - 1)
+		}
+	case tea.MouseMsg:
+		if msg.Action != tea.MouseActionRelease || msg.Button != tea.MouseButtonLeft {
+			return m, nil
+		}
+
+		if zone.Get(m.zonePrefix + "close").InBounds(msg) {
+			m.Show = false
+			return m, nil
+		}
+
+		if zone.Get(m.zonePrefix + "save").InBounds(msg) {
+			return m.save()
+		}
+
+		for i := range m.inputs {
+			if zone.Get(m.inputMark(i)).InBounds(msg) {
+				return m.focusInput(i)
+			}
+		}
+
+		return m, nil
+	}
+
+	cmds := make([]tea.Cmd, len(m.inputs))
+	for i := range m.inputs {
+		m.inputs[i], cmds[i] = m.inputs[i].Update(msg)
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m EncryptionPasswordsModel) View() string {
+	const width = 70
+	header := lipgloss.NewStyle().
+		Padding(0, 1).
+		Width(width).
+		Background(styles.AccentColor).
+		Render(fmt.Sprintf("Encryption passwords: %s", m.folderLabel))
+
+	var doc strings.Builder
+	if len(m.inputs) == 0 {
+		doc.WriteString("Folder isn't shared with other devices.")
+	}
+	for i, input := range m.inputs {
+		doc.WriteString(m.deviceNames[i])
+		doc.WriteString("\n")
+		doc.WriteString(zone.Mark(m.inputMark(i), input.View()))
+		doc.WriteString("\n\n")
+	}
+	doc.WriteString(lipgloss.NewStyle().Italic(true).Render(
+		"Devices with a password receive this folder encrypted. " +
+			"Leave empty to share it unencrypted.",
+	))
+	switch {
+	case m.err != nil:
+		doc.WriteString("\n\n")
+		doc.WriteString(
+			lipgloss.NewStyle().Foreground(styles.ErrorColor).Render(m.err.Error()),
+		)
+	case m.saving:
+		doc.WriteString("\n\n")
+		doc.WriteString(lipgloss.NewStyle().Faint(true).Render("Saving…"))
+	}
+
+	body := lipgloss.NewStyle().Padding(1, 1).Width(width).Render(doc.String())
+	actions := lipgloss.PlaceHorizontal(width, lipgloss.Right, lipgloss.JoinHorizontal(lipgloss.Top,
+		zone.Mark(m.zonePrefix+"save", styles.BtnStyleV2.Render("Save")),
+		"  ",
+		zone.Mark(m.zonePrefix+"close", styles.BtnStyleV2.Render("Close")),
+	))
+
+	return lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Render(
+		lipgloss.JoinVertical(lipgloss.Left, header, body, actions),
+	)
+}
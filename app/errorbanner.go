@@ -0,0 +1,52 @@
+package app
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+)
+
+const (
+	ERROR_BANNER_TIMEOUT     = 10 * time.Second
+	ERROR_BANNER_DISMISS_BTN = "dismiss-error-banner"
+)
+
+var dismissErrorKeys = key.NewBinding(
+	key.WithKeys("x"),
+	key.WithHelp("x", "dismiss the error banner"),
+)
+
+// ErrorBanner is the last failed request. It is shown on top of the dashboard,
+// which keeps rendering the data fetched before, until it expires or is dismissed.
+type ErrorBanner struct {
+	Err error
+	At  time.Time
+}
+
+func newErrorBanner(err error, currentTime time.Time) ErrorBanner {
+	return ErrorBanner{Err: err, At: currentTime}
+}
+
+func (b ErrorBanner) Visible(currentTime time.Time) bool {
+	return b.Err != nil && currentTime.Sub(b.At) < ERROR_BANNER_TIMEOUT
+}
+
+func viewErrorBanner(banner ErrorBanner, currentTime time.Time, width int) string {
+	if !banner.Visible(currentTime) {
+		return ""
+	}
+
+	dismiss := zone.Mark(ERROR_BANNER_DISMISS_BTN, styles.BtnStyleV2.Render("Dismiss (x)"))
+	style := lipgloss.NewStyle().
+		Background(styles.ErrorColor).
+		Foreground(lipgloss.Color("#ffffff")).
+		Padding(0, 1)
+	message := style.
+		Width(max(0, width-lipgloss.Width(dismiss)-1)).
+		Render("✗ " + banner.Err.Error())
+
+	return lipgloss.JoinHorizontal(lipgloss.Center, message, " ", dismiss)
+}
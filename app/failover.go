@@ -0,0 +1,162 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// how often the primary url is retried while running on a fallback
+	PRIMARY_PROBE_INTERVAL = 30 * time.Second
+	PRIMARY_PROBE_TIMEOUT  = 5 * time.Second
+	// answers without an api key, any answer means the primary is back
+	PRIMARY_PROBE_PATH = "/rest/noauth/health"
+	// a dead url fails this fast instead of after the OS connect timeout
+	DIAL_TIMEOUT = 5 * time.Second
+)
+
+// failoverTransport sends requests to the first reachable base url. Requests are
+// always built against the primary url and rewritten to the active one.
+type failoverTransport struct {
+	base http.RoundTripper
+	urls []url.URL
+
+	mu        sync.Mutex
+	active    int
+	lastProbe time.Time
+	probing   bool
+}
+
+func newFailoverTransport(base http.RoundTripper, urls []url.URL) *failoverTransport {
+	return &failoverTransport{base: base, urls: urls}
+}
+
+func (t *failoverTransport) Active() url.URL {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.urls[t.active]
+}
+
+// attemptOrder returns the url indexes to try, the active one first.
+func (t *failoverTransport) attemptOrder() []int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	order := []int{t.active}
+	for i := range t.urls {
+		if i != t.active {
+			order = append(order, i)
+		}
+	}
+
+	return order
+}
+
+// maybeProbePrimary probes the primary url from time to time while running on a
+// fallback. The probe runs in the background, requests keep going to the fallback.
+func (t *failoverTransport) maybeProbePrimary() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.active == 0 || t.probing || time.Since(t.lastProbe) <= PRIMARY_PROBE_INTERVAL {
+		return
+	}
+	t.probing = true
+	t.lastProbe = time.Now()
+	go t.probePrimary()
+}
+
+func (t *failoverTransport) probePrimary() {
+	ctx, cancel := context.WithTimeout(context.Background(), PRIMARY_PROBE_TIMEOUT)
+	defer cancel()
+
+	var resp *http.Response
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		t.urls[0].JoinPath(PRIMARY_PROBE_PATH).String(),
+		nil,
+	)
+	if err == nil {
+		resp, err = t.base.RoundTrip(req)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.probing = false
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+	t.active = 0
+}
+
+// retriable tells if a failed attempt can be sent to the next url. Requests that
+// change state may have reached syncthing before failing, only those that never
+// left, because the connection couldn't be made, are sent again.
+func retriable(req *http.Request, err error) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func (t *failoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.maybeProbePrimary()
+
+	var lastErr error
+	order := t.attemptOrder()
+	for _, i := range order {
+		attempt := req.Clone(req.Context())
+		attempt.URL = rebase(req.URL, t.urls[0], t.urls[i])
+		attempt.Host = ""
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attempt.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(attempt)
+		if err != nil {
+			lastErr = err
+			if !retriable(req, err) {
+				break
+			}
+			continue
+		}
+
+		// a probe may have brought the primary back meanwhile, only a failover moves
+		if i != order[0] {
+			t.mu.Lock()
+			t.active = i
+			t.mu.Unlock()
+		}
+		return resp, nil
+	}
+
+	return nil, lastErr
+}
+
+func rebase(target *url.URL, from, to url.URL) *url.URL {
+	rebased := *target
+	rebased.Scheme = to.Scheme
+	rebased.Host = to.Host
+	rebased.User = to.User
+	path := strings.TrimPrefix(target.Path, strings.TrimSuffix(from.Path, "/"))
+	rebased.Path = strings.TrimSuffix(to.Path, "/") + path
+	rebased.RawPath = ""
+
+	return &rebased
+}
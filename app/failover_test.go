@@ -0,0 +1,148 @@
+package app
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// serverURL is a test server counting the requests it answers.
+func serverURL(t *testing.T, hits *atomic.Int32) url.URL {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+	}))
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *u
+}
+
+// droppingURL accepts connections and closes them without an answer, like a
+// primary dying while it handles the request.
+func droppingURL(t *testing.T) url.URL {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			buf := make([]byte, 1024)
+			_, _ = conn.Read(buf)
+			conn.Close()
+		}
+	}()
+
+	return url.URL{Scheme: "http", Host: listener.Addr().String()}
+}
+
+// closedURL refuses connections, like a primary that is down.
+func closedURL(t *testing.T) url.URL {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	return url.URL{Scheme: "http", Host: addr}
+}
+
+func TestFailoverRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		primary      func(t *testing.T) url.URL
+		wantFallback bool
+	}{
+		{name: "refused get", method: http.MethodGet, primary: closedURL, wantFallback: true},
+		{name: "refused post", method: http.MethodPost, primary: closedURL, wantFallback: true},
+		{name: "dropped get", method: http.MethodGet, primary: droppingURL, wantFallback: true},
+		{name: "dropped post", method: http.MethodPost, primary: droppingURL},
+		{name: "dropped patch", method: http.MethodPatch, primary: droppingURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits atomic.Int32
+			primary := tt.primary(t)
+			transport := newFailoverTransport(
+				http.DefaultTransport,
+				[]url.URL{primary, serverURL(t, &hits)},
+			)
+			req, err := http.NewRequest(tt.method, primary.JoinPath(DB_SCAN).String(), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := transport.RoundTrip(req)
+			if err == nil {
+				resp.Body.Close()
+			}
+			if got := hits.Load() == 1; got != tt.wantFallback {
+				t.Errorf("sent to the fallback = %v, want %v (error %v)",
+					got, tt.wantFallback, err)
+			}
+			if !tt.wantFallback && err == nil {
+				t.Error("RoundTrip() error = nil, want the primary failure")
+			}
+		})
+	}
+}
+
+func TestFailoverProbesInTheBackground(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == PRIMARY_PROBE_PATH {
+			<-release
+		}
+	}))
+	t.Cleanup(server.Close)
+	primary, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fallbackHits atomic.Int32
+	transport := newFailoverTransport(
+		http.DefaultTransport,
+		[]url.URL{*primary, serverURL(t, &fallbackHits)},
+	)
+	transport.active = 1
+
+	req, err := http.NewRequest(http.MethodGet, primary.JoinPath(SYSTEM_STATUS).String(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the probe hangs until released, the request must not wait for it
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if fallbackHits.Load() != 1 {
+		t.Errorf("request sent to the fallback = %v, want it while probing", fallbackHits.Load())
+	}
+	close(release)
+
+	deadline := time.Now().Add(PRIMARY_PROBE_TIMEOUT)
+	for transport.Active() != *primary && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if transport.Active() != *primary {
+		t.Errorf("Active() = %v after the probe, want the primary back", transport.Active())
+	}
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pdrolopes/syncthing_TUI/syncthing"
+	"github.com/samber/lo"
+)
+
+var filesystemFilterKeys = key.NewBinding(
+	key.WithKeys("F"),
+	key.WithHelp("F", "filter folders by filesystem type"),
+)
+
+// filesystemType defaults to basic like syncthing does when the config leaves it empty.
+func filesystemType(config syncthing.FolderConfig) string {
+	if config.FilesystemType == "" {
+		return "basic"
+	}
+	return config.FilesystemType
+}
+
+func filterFilesystemType(folders []FolderViewModel, filter string) []FolderViewModel {
+	if filter == "" {
+		return folders
+	}
+
+	return lo.Filter(folders, func(f FolderViewModel, index int) bool {
+		return filesystemType(f.Config) == filter
+	})
+}
+
+// nextFilesystemFilter cycles through the filesystem types in use, then back to
+// all folders.
+func nextFilesystemFilter(folders []FolderViewModel, filter string) string {
+	types := lo.Uniq(lo.Map(folders, func(f FolderViewModel, index int) string {
+		return filesystemType(f.Config)
+	}))
+	slices.Sort(types)
+
+	next := slices.Index(types, filter) + 1
+	if filter == "" {
+		next = 0
+	}
+	if next >= len(types) {
+		return ""
+	}
+
+	return types[next]
+}
+
+func viewFilesystemFilter(filter string, hidden int) string {
+	if filter == "" {
+		return ""
+	}
+
+	return lipgloss.NewStyle().Italic(true).Faint(true).Padding(0, 1).Render(
+		fmt.Sprintf("only %s folders, %d hidden (F to change)", filter, hidden))
+}
@@ -0,0 +1,210 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+	"github.com/samber/lo"
+)
+
+// advancedFolderField is an editable numeric folder option. Zero always lets
+// syncthing pick its default.
+type advancedFolderField struct {
+	label string
+	min   int
+	max   int
+}
+
+var advancedFolderFields = []advancedFolderField{
+	{label: "Copiers", min: 0, max: 32},
+	{label: "Hashers", min: 0, max: 32},
+	{label: "Puller Max Pending KiB", min: 0, max: 1024 * 1024},
+	{label: "Max Concurrent Writes", min: 0, max: 64},
+}
+
+type AdvancedFolderValues struct {
+	Copiers             int `json:"copiers"`
+	Hashers             int `json:"hashers"`
+	PullerMaxPendingKiB int `json:"pullerMaxPendingKiB"`
+	MaxConcurrentWrites int `json:"maxConcurrentWrites"`
+}
+
+// FolderAdvancedModel shows the performance tuning options of a folder.
+type FolderAdvancedModel struct {
+	Show            bool
+	folderID        string
+	folderLabel     string
+	filesystemType  string
+	blockPullOrder  string
+	copyRangeMethod string
+	inputs          []textinput.Model
+	focus           int
+	err             error
+	zonePrefix      string
+	httpData        HttpData
+}
+
+func NewFolderAdvanced(folder FolderViewModel, httpData HttpData) FolderAdvancedModel {
+	values := []int{
+		folder.Config.Copiers,
+		folder.Config.Hashers,
+		folder.Config.PullerMaxPendingKiB,
+		folder.Config.MaxConcurrentWrites,
+	}
+	inputs := lo.Map(values, func(value int, index int) textinput.Model {
+		input := textinput.New()
+		input.CharLimit = 8
+		input.Width = 10
+		input.Placeholder = "0"
+		input.SetValue(strconv.Itoa(value))
+		return input
+	})
+	inputs[0].Focus()
+
+	return FolderAdvancedModel{
+		Show:            true,
+		folderID:        folder.Config.ID,
+		folderLabel:     folderName(folder),
+		filesystemType:  filesystemType(folder.Config),
+		blockPullOrder:  folder.Config.BlockPullOrder,
+		copyRangeMethod: folder.Config.CopyRangeMethod,
+		inputs:          inputs,
+		zonePrefix:      zone.NewPrefix(),
+		httpData:        httpData,
+	}
+}
+
+func (m FolderAdvancedModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m FolderAdvancedModel) inputMark(i int) string {
+	return fmt.Sprintf("%sinput/%d", m.zonePrefix, i)
+}
+
+func (m FolderAdvancedModel) focusInput(i int) (FolderAdvancedModel, tea.Cmd) {
+	m.focus = (i + len(m.inputs)) % len(m.inputs)
+	for j := range m.inputs {
+		m.inputs[j].Blur()
+	}
+
+	return m, m.inputs[m.focus].Focus()
+}
+
+func (m FolderAdvancedModel) save() (FolderAdvancedModel, tea.Cmd) {
+	values := make([]int, len(m.inputs))
+	for i, input := range m.inputs {
+		field := advancedFolderFields[i]
+		value, err := strconv.Atoi(strings.TrimSpace(input.Value()))
+		if err != nil || value < field.min || value > field.max {
+			m.err = fmt.Errorf("%s must be between %d and %d", field.label, field.min, field.max)
+			m, _ = m.focusInput(i)
+			return m, nil
+		}
+		values[i] = value
+	}
+
+	m.Show = false
+	return m, updateFolderAdvanced(m.httpData, m.folderID, AdvancedFolderValues{
+		Copiers:             values[0],
+		Hashers:             values[1],
+		PullerMaxPendingKiB: values[2],
+		MaxConcurrentWrites: values[3],
+	})
+}
+
+func (m FolderAdvancedModel) Update(msg tea.Msg) (FolderAdvancedModel, tea.Cmd) {
+	if !m.Show {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyEsc:
+			m.Show = false
+			return m, nil
+		case tea.KeyEnter:
+			return m.save()
+		case tea.KeyTab, tea.KeyDown:
+			return m.focusInput(m.focus + 1)
+		case tea.KeyShiftTab, tea.KeyUp:
+			return m.focusInput(m.focus - 1)
+		}
+	case tea.MouseMsg:
+		if msg.Action != tea.MouseActionRelease || msg.Button != tea.MouseButtonLeft {
+			return m, nil
+		}
+
+		if zone.Get(m.zonePrefix + "close").InBounds(msg) {
+			m.Show = false
+			return m, nil
+		}
+
+		if zone.Get(m.zonePrefix + "save").InBounds(msg) {
+			return m.save()
+		}
+
+		for i := range m.inputs {
+			if zone.Get(m.inputMark(i)).InBounds(msg) {
+				return m.focusInput(i)
+			}
+		}
+
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.inputs[m.focus], cmd = m.inputs[m.focus].Update(msg)
+	return m, cmd
+}
+
+func (m FolderAdvancedModel) View() string {
+	const width = 60
+	header := lipgloss.NewStyle().
+		Padding(0, 1).
+		Width(width).
+		Background(styles.AccentColor).
+		Render(fmt.Sprintf("Advanced: %s", m.folderLabel))
+
+	t := spaceAroundTable().Width(width-2).
+		Row("Filesystem Type", m.filesystemType).
+		Row("Block Pull Order", lo.Ternary(m.blockPullOrder == "", "standard", m.blockPullOrder)).
+		Row("Copy Range Method", lo.Ternary(m.copyRangeMethod == "", "standard", m.copyRangeMethod))
+	for i, field := range advancedFolderFields {
+		t = t.Row(
+			fmt.Sprintf("%s (%d-%d)", field.label, field.min, field.max),
+			zone.Mark(m.inputMark(i), m.inputs[i].View()),
+		)
+	}
+
+	rows := []string{
+		t.Render(),
+		"",
+		lipgloss.NewStyle().Italic(true).Render("0 uses the syncthing default."),
+	}
+	if m.err != nil {
+		rows = append(rows, "",
+			lipgloss.NewStyle().Foreground(styles.ErrorColor).Render(m.err.Error()))
+	}
+
+	body := lipgloss.NewStyle().
+		Padding(1, 1).
+		Width(width).
+		Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+	actions := lipgloss.PlaceHorizontal(width, lipgloss.Right, lipgloss.JoinHorizontal(lipgloss.Top,
+		zone.Mark(m.zonePrefix+"save", styles.BtnStyleV2.Render("Save")),
+		"  ",
+		zone.Mark(m.zonePrefix+"close", styles.BtnStyleV2.Render("Close")),
+	))
+
+	return lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Render(
+		lipgloss.JoinVertical(lipgloss.Left, header, body, actions),
+	)
+}
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// FolderFatal is why syncthing stopped a folder and what brings it back.
+type FolderFatal int
+
+const (
+	FolderFatalOther FolderFatal = iota
+	// the marker directory is gone, syncthing won't touch the folder fearing an
+	// unmounted disk would look like every file was deleted
+	FolderFatalMarkerMissing
+	FolderFatalPathMissing
+)
+
+// folderFatalCause is the error syncthing stopped the folder with, empty when
+// the folder runs.
+func folderFatalCause(folder FolderViewModel) string {
+	return cmp.Or(folder.Status.Error, folder.Status.Invalid)
+}
+
+// classifyFolderFatal matches the syncthing error messages, which aren't typed
+// in the REST API.
+func classifyFolderFatal(cause string) FolderFatal {
+	switch {
+	case strings.Contains(cause, "marker missing"):
+		return FolderFatalMarkerMissing
+	case strings.Contains(cause, "path missing"):
+		return FolderFatalPathMissing
+	default:
+		return FolderFatalOther
+	}
+}
+
+func folderFatalHint(fatal FolderFatal) string {
+	switch fatal {
+	case FolderFatalMarkerMissing:
+		return "Check the folder content is all there, then create the marker."
+	case FolderFatalPathMissing:
+		return "Mount the disk or restore the folder path, then rescan."
+	default:
+		return "Fix the cause, then rescan."
+	}
+}
+
+type CreatedFolderMarkerMsg struct {
+	folderID string
+	err      error
+}
+
+// createFolderMarker recreates the marker syncthing checks before using a folder.
+// It can only be done when syncthing runs on this machine.
+func createFolderMarker(httpData HttpData, folder FolderViewModel) tea.Cmd {
+	return func() tea.Msg {
+		if !isLocalURL(httpData.url) {
+			return CreatedFolderMarkerMsg{
+				folderID: folder.Config.ID,
+				err: errors.New(
+					"the folder marker can only be created when syncthing runs on this machine"),
+			}
+		}
+
+		path := expandHome(folder.Config.Path)
+		if info, err := os.Stat(path); err != nil || !info.IsDir() {
+			return CreatedFolderMarkerMsg{
+				folderID: folder.Config.ID,
+				err:      fmt.Errorf("folder path %s is not a directory", path),
+			}
+		}
+
+		marker := folder.Config.MarkerName
+		if marker == "" {
+			marker = ".stfolder"
+		}
+		err := os.MkdirAll(filepath.Join(path, marker), 0o700)
+		return CreatedFolderMarkerMsg{folderID: folder.Config.ID, err: err}
+	}
+}
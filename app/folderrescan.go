@@ -0,0 +1,146 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+	"github.com/samber/lo"
+)
+
+var folderRescanKeys = key.NewBinding(
+	key.WithKeys("W"),
+	key.WithHelp("W", "edit rescan interval and watcher of the selected folder"),
+)
+
+// FolderRescanModel edits how a folder notices changes: the periodic full
+// rescans and the filesystem watcher.
+type FolderRescanModel struct {
+	Show        bool
+	folderID    string
+	folderLabel string
+	input       textinput.Model
+	watcher     bool
+	err         error
+	zonePrefix  string
+	httpData    HttpData
+}
+
+func NewFolderRescan(folder FolderViewModel, httpData HttpData) FolderRescanModel {
+	input := textinput.New()
+	input.CharLimit = 8
+	input.Width = 10
+	input.SetValue(strconv.Itoa(folder.Config.RescanIntervalS))
+	input.Focus()
+
+	return FolderRescanModel{
+		Show:        true,
+		folderID:    folder.Config.ID,
+		folderLabel: folderName(folder),
+		input:       input,
+		watcher:     folder.Config.FsWatcherEnabled,
+		zonePrefix:  zone.NewPrefix(),
+		httpData:    httpData,
+	}
+}
+
+func (m FolderRescanModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// save leaves the card as it is, the ConfigSaved event brings the new values.
+func (m FolderRescanModel) save() (FolderRescanModel, tea.Cmd) {
+	interval, err := strconv.Atoi(strings.TrimSpace(m.input.Value()))
+	if err != nil || interval <= 0 {
+		m.err = errors.New("the rescan interval must be a positive number of seconds")
+		return m, nil
+	}
+
+	m.Show = false
+	return m, updateFolderRescan(m.httpData, m.folderID, interval, m.watcher)
+}
+
+func (m FolderRescanModel) Update(msg tea.Msg) (FolderRescanModel, tea.Cmd) {
+	if !m.Show {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyEsc:
+			m.Show = false
+			return m, nil
+		case tea.KeyEnter:
+			return m.save()
+		case tea.KeyTab, tea.KeyShiftTab:
+			m.watcher = !m.watcher
+			return m, nil
+		}
+	case tea.MouseMsg:
+		if msg.Action != tea.MouseActionRelease || msg.Button != tea.MouseButtonLeft {
+			return m, nil
+		}
+
+		switch {
+		case zone.Get(m.zonePrefix + "watcher").InBounds(msg):
+			m.watcher = !m.watcher
+		case zone.Get(m.zonePrefix + "save").InBounds(msg):
+			return m.save()
+		case zone.Get(m.zonePrefix + "close").InBounds(msg):
+			m.Show = false
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m FolderRescanModel) View() string {
+	const width = 60
+	header := lipgloss.NewStyle().
+		Padding(0, 1).
+		Width(width).
+		Background(styles.AccentColor).
+		Render(fmt.Sprintf("Rescans: %s", m.folderLabel))
+
+	t := spaceAroundTable().Width(width-2).
+		Row("Full Rescan Interval (s)", m.input.View()).
+		Row("Watch for Changes", zone.Mark(m.zonePrefix+"watcher",
+			lo.Ternary(m.watcher, "[x] Enabled", "[ ] Disabled")))
+
+	rows := []string{
+		t.Render(),
+		"",
+		lipgloss.NewStyle().Italic(true).Render(
+			"The watcher picks up changes as they happen, the full rescans catch what " +
+				"it missed. [tab] toggles the watcher."),
+	}
+	if m.err != nil {
+		rows = append(rows, "",
+			lipgloss.NewStyle().Foreground(styles.ErrorColor).Render(m.err.Error()))
+	}
+
+	body := lipgloss.NewStyle().
+		Padding(1, 1).
+		Width(width).
+		Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+	actions := lipgloss.PlaceHorizontal(width, lipgloss.Right, lipgloss.JoinHorizontal(lipgloss.Top,
+		zone.Mark(m.zonePrefix+"save", styles.BtnStyleV2.Render("Save")),
+		"  ",
+		zone.Mark(m.zonePrefix+"close", styles.BtnStyleV2.Render("Close")),
+	))
+
+	return lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Render(
+		lipgloss.JoinVertical(lipgloss.Left, header, body, actions),
+	)
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/samber/lo"
+)
+
+// items becoming active within this window after the first one scroll the
+// dashboard a single time
+const FOLLOW_ACTIVITY_DEBOUNCE = 2 * time.Second
+
+var followActivityKeys = key.NewBinding(
+	key.WithKeys("w"),
+	key.WithHelp("w", "scroll to folders that start syncing and devices that connect"),
+)
+
+// FollowActivityDueMsg ends the debounce window, the dashboard scrolls to what
+// became active during it.
+type FollowActivityDueMsg struct{}
+
+// FollowActivity scrolls the dashboard to the folders that start syncing and the
+// devices that connect, so they show up in a long list. The sort order is left
+// alone.
+type FollowActivity struct {
+	// became active during the debounce window, in that order
+	queued []string
+	// active IDs on the previous tick
+	activeFolders []string
+	activeDevices []string
+	primed        bool
+	debouncing    bool
+}
+
+func activeFolderIDs(folders []FolderViewModel) []string {
+	return lo.FilterMap(folders, func(f FolderViewModel, index int) (string, bool) {
+		status := folderStatus(f)
+		return f.Config.ID, status == Syncing || status == SyncPrepare
+	})
+}
+
+func connectedDeviceIDs(devices []DeviceViewModel) []string {
+	return lo.FilterMap(devices, func(d DeviceViewModel, index int) (string, bool) {
+		return d.Config.DeviceID, d.Connection.B.Connected
+	})
+}
+
+// Observe queues what became active since the last call. The first item queued
+// opens the debounce window.
+func (f FollowActivity) Observe(
+	folders []FolderViewModel,
+	devices []DeviceViewModel,
+) (FollowActivity, tea.Cmd) {
+	activeFolders, activeDevices := activeFolderIDs(folders), connectedDeviceIDs(devices)
+	// what is already active at startup isn't news
+	if f.primed {
+		f.queued = lo.Union(f.queued, lo.Without(activeFolders, f.activeFolders...))
+		f.queued = lo.Union(f.queued, lo.Without(activeDevices, f.activeDevices...))
+	}
+	f.activeFolders, f.activeDevices, f.primed = activeFolders, activeDevices, true
+
+	if len(f.queued) == 0 || f.debouncing {
+		return f, nil
+	}
+	f.debouncing = true
+
+	return f, tea.Tick(FOLLOW_ACTIVITY_DEBOUNCE, func(time.Time) tea.Msg {
+		return FollowActivityDueMsg{}
+	})
+}
+
+// followActivity scrolls to the first queued card still listed, like the
+// selection does.
+func (m model) followActivity() model {
+	queued := m.follow.queued
+	m.follow.queued, m.follow.debouncing = nil, false
+	for _, id := range queued {
+		if revealed, found := m.revealCard(id); found {
+			return revealed
+		}
+	}
+
+	return m
+}
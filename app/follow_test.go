@@ -0,0 +1,71 @@
+package app
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pdrolopes/syncthing_TUI/syncthing"
+	"github.com/samber/lo"
+)
+
+func TestFollowActivityObserve(t *testing.T) {
+	devices := []DeviceViewModel{
+		{Config: syncthing.DeviceConfig{DeviceID: "A"}},
+		{Config: syncthing.DeviceConfig{DeviceID: "B"}},
+	}
+	connect := func(devices []DeviceViewModel, ids ...string) []DeviceViewModel {
+		return lo.Map(devices, func(d DeviceViewModel, index int) DeviceViewModel {
+			if lo.Contains(ids, d.Config.DeviceID) {
+				d.Connection = lo.T2(true, syncthing.Connection{Connected: true})
+			}
+			return d
+		})
+	}
+
+	follow, cmd := FollowActivity{}.Observe(nil, connect(devices, "A"))
+	if cmd != nil || len(follow.queued) != 0 {
+		t.Errorf("first observe queued %v, want what is active at startup left out", follow.queued)
+	}
+	follow, cmd = follow.Observe(nil, connect(devices, "A", "B"))
+	if cmd == nil || !reflect.DeepEqual(follow.queued, []string{"B"}) {
+		t.Errorf("connected device queued %v, want B and the debounce started", follow.queued)
+	}
+	follow, cmd = follow.Observe(nil, connect(devices, "B"))
+	follow, cmd2 := follow.Observe(nil, connect(devices, "A", "B"))
+	if cmd != nil || cmd2 != nil {
+		t.Error("activity during the debounce window started another one")
+	}
+	if !reflect.DeepEqual(follow.queued, []string{"B", "A"}) {
+		t.Errorf("queued %v, want both devices for a single scroll", follow.queued)
+	}
+}
+
+func TestFollowActivityScrolls(t *testing.T) {
+	config := syncthing.Config{}
+	for i := range 30 {
+		config.Devices = append(config.Devices, syncthing.DeviceConfig{
+			DeviceID: fmt.Sprintf("DEVICE%02d", i),
+			Name:     fmt.Sprintf("device %02d", i),
+		})
+	}
+	m := NewModel(Options{})
+	m.settings.FollowActivity = true
+	m = updateAll(m, tea.WindowSizeMsg{Width: 120, Height: 40}, FetchedConfig{config: config})
+	before := m.selectableIDs()
+
+	last := config.Devices[len(config.Devices)-1].DeviceID
+	m.follow = FollowActivity{queued: []string{"GONE", last}, debouncing: true}
+	m = updateAll(m, FollowActivityDueMsg{})
+
+	if m.viewport.YOffset == 0 {
+		t.Error("dashboard didn't scroll to the connected device")
+	}
+	if m.follow.debouncing || len(m.follow.queued) != 0 {
+		t.Errorf("follow = %+v after the scroll, want the queue emptied", m.follow)
+	}
+	if after := m.selectableIDs(); !reflect.DeepEqual(before, after) {
+		t.Errorf("order changed to %v, want the sort order kept", after)
+	}
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+	"github.com/pdrolopes/syncthing_TUI/syncthing"
+)
+
+const GUI_THEME_MARK = "gui-theme"
+
+// themes bundled with the syncthing web GUI
+var guiThemes = []string{"default", "light", "dark", "black"}
+
+// guiThemeName treats an empty theme like syncthing does, as the default one.
+func guiThemeName(theme string) string {
+	if theme == "" {
+		return "default"
+	}
+	return theme
+}
+
+// nextGUITheme cycles through the bundled themes, an unknown theme moves back to
+// the default one.
+func nextGUITheme(theme string) string {
+	index := slices.Index(guiThemes, guiThemeName(theme))
+	if index < 0 {
+		return guiThemes[0]
+	}
+
+	return guiThemes[(index+1)%len(guiThemes)]
+}
+
+func viewGUITheme(theme string) string {
+	label := guiThemeName(theme)
+	if !slices.Contains(guiThemes, label) {
+		label = lipgloss.NewStyle().Foreground(styles.WarningColor).Render("⚠ " + label)
+	}
+
+	return zone.Mark(GUI_THEME_MARK, styles.BtnStyleV2.Render(label))
+}
+
+// cycleGUITheme saves the next theme in the syncthing config, the panel shows it
+// once syncthing sends the saved config back.
+func (m model) cycleGUITheme() (model, tea.Cmd) {
+	if m.putConfig == nil {
+		return m, nil
+	}
+
+	theme := nextGUITheme(m.thisDeviceStatus.GUITheme)
+	return m, m.putConfig(m.httpData, func(config syncthing.Config) syncthing.Config {
+		config.GUI.Theme = theme
+		return config
+	})
+}
@@ -0,0 +1,187 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+	"github.com/samber/lo"
+)
+
+var helpKeys = key.NewBinding(
+	key.WithKeys("?"),
+	key.WithHelp("?", "show/hide this help"),
+)
+
+type helpGroup struct {
+	title    string
+	bindings []key.Binding
+}
+
+// helpGroups lists every dashboard action, a new keybinding belongs in one of
+// them.
+var helpGroups = []helpGroup{
+	{
+		title: "Navigate",
+		bindings: []key.Binding{
+			selectNextKeys,
+			selectPreviousKeys,
+			switchSectionKeys,
+			toggleExpandKeys,
+			jumpKeys,
+			searchKeys,
+			scrollPageDownKeys,
+			scrollPageUpKeys,
+			scrollDownKeys,
+			scrollUpKeys,
+			scrollTopKeys,
+			scrollBottomKeys,
+		},
+	},
+	{
+		title: "Selected folder or device",
+		bindings: []key.Binding{
+			pinKeys,
+			folderLabelKeys,
+			conflictsKeys,
+			folderAdvancedKeys,
+			folderRescanKeys,
+			folderIgnoresKeys,
+			folderInviteKeys,
+			ignorePermsKeys,
+			deviceGroupKeys,
+		},
+	},
+	{
+		title: "View",
+		bindings: []key.Binding{
+			toggleStatusKeys,
+			toggleTrafficViewKeys,
+			timeStyleKeys,
+			sizeStyleKeys,
+			sortKeys,
+			deviceFilterKeys,
+			filesystemFilterKeys,
+			problemsOnlyKeys,
+			followActivityKeys,
+			groupDevicesKeys,
+			pendingSortKeys,
+		},
+	},
+	{
+		title: "Syncthing",
+		bindings: []key.Binding{
+			refreshKeys,
+			reloadConfigKeys,
+			openWebGUIKeys,
+			eventTimelineKeys,
+			systemLogKeys,
+			deviceIDQRKeys,
+			pausedFoldersKeys,
+			minHomeDiskFreeKeys,
+			reconnectAllKeys,
+			upgradeKeys,
+			dismissErrorKeys,
+			helpKeys,
+			quitKeys,
+		},
+	},
+}
+
+// HelpModel lists the keybindings, grouped in as many columns as the terminal
+// fits.
+type HelpModel struct {
+	Show bool
+	help help.Model
+	// terminal width
+	width      int
+	zonePrefix string
+}
+
+func NewHelp(width int) HelpModel {
+	return HelpModel{
+		Show:       true,
+		help:       help.New(),
+		width:      width,
+		zonePrefix: zone.NewPrefix(),
+	}
+}
+
+func (m HelpModel) Resize(width int) HelpModel {
+	m.width = width
+	return m
+}
+
+func (m HelpModel) Update(msg tea.Msg) (HelpModel, tea.Cmd) {
+	if !m.Show {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q", "?":
+			m.Show = false
+		}
+	case tea.MouseMsg:
+		if msg.Action != tea.MouseActionRelease || msg.Button != tea.MouseButtonLeft {
+			return m, nil
+		}
+
+		if zone.Get(m.zonePrefix+"close").InBounds(msg) ||
+			!zone.Get(m.zonePrefix+"area").InBounds(msg) {
+			m.Show = false
+		}
+	}
+
+	return m, nil
+}
+
+func (m HelpModel) View() string {
+	// border and padding around the body
+	const frame, gap = 4, 4
+	columns := lo.Map(helpGroups, func(g helpGroup, index int) string {
+		return lipgloss.JoinVertical(lipgloss.Left,
+			lipgloss.NewStyle().Bold(true).Render(g.title),
+			m.help.FullHelpView([][]key.Binding{g.bindings}),
+		)
+	})
+
+	// place the groups side by side while they fit, then start a new row
+	var rows, row []string
+	rowWidth := 0
+	for _, column := range columns {
+		if len(row) > 0 && rowWidth+gap+lipgloss.Width(column) > m.width-frame {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+			row, rowWidth = nil, 0
+		}
+		if len(row) > 0 {
+			column = lipgloss.NewStyle().PaddingLeft(gap).Render(column)
+		}
+		row = append(row, column)
+		rowWidth += lipgloss.Width(column)
+	}
+	rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+	body := strings.Join(rows, "\n\n")
+
+	width := lipgloss.Width(body) + 2
+	header := lipgloss.NewStyle().
+		Padding(0, 1).
+		Width(width).
+		Background(styles.AccentColor).
+		Render("Keybindings")
+	body = lipgloss.NewStyle().Padding(1, 1).Width(width).Render(body)
+	actions := lipgloss.PlaceHorizontal(width, lipgloss.Right,
+		zone.Mark(m.zonePrefix+"close", styles.BtnStyleV2.Render("Close")))
+
+	return zone.Mark(
+		m.zonePrefix+"area",
+		lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Render(
+			lipgloss.JoinVertical(lipgloss.Left, header, body, actions),
+		),
+	)
+}
@@ -2,6 +2,7 @@ package app
 
 import (
 	"fmt"
+	"time"
 )
 
 // TODO rethink this functions
@@ -15,6 +16,11 @@ func HumanizeDuration(seconds int64) string {
 		secondsPerYear   = 31557600 // Approximate: 365.25 days per year
 	)
 
+	// clock skew or bad data can make durations negative
+	if seconds < 0 {
+		seconds = 0
+	}
+
 	// Calculate years
 	years := seconds / secondsPerYear
 	seconds %= secondsPerYear
@@ -107,3 +113,71 @@ func ScanDuration(seconds int64) string {
 
 	return result
 }
+
+// Countdown formats a duration as MM:SS, or HH:MM:SS when longer than an hour.
+func Countdown(d time.Duration) string {
+	totalSeconds := int64(d.Seconds())
+	if totalSeconds < 0 {
+		totalSeconds = 0
+	}
+
+	hours := totalSeconds / 3600
+	minutes := (totalSeconds % 3600) / 60
+	seconds := totalSeconds % 60
+
+	if hours > 0 {
+		return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
+	}
+
+	return fmt.Sprintf("%02d:%02d", minutes, seconds)
+}
+
+// TimeAgo formats how long ago t happened, e.g. "5 min ago".
+func TimeAgo(t, now time.Time) string {
+	if t.IsZero() {
+		return "unknown"
+	}
+
+	elapsed := now.Sub(t)
+	switch {
+	// the syncthing clock can be a bit ahead of this one
+	case elapsed <= -time.Minute:
+		return "in the future (clock skew)"
+	case elapsed < time.Minute:
+		return "just now"
+	case elapsed < time.Hour:
+		return fmt.Sprintf("%d min ago", int(elapsed.Minutes()))
+	case elapsed < 24*time.Hour:
+		return fmt.Sprintf("%d h ago", int(elapsed.Hours()))
+	case elapsed < 48*time.Hour:
+		return "yesterday"
+	}
+
+	return fmt.Sprintf("%d days ago", int(elapsed.Hours()/24))
+}
+
+// TimeStyle chooses between absolute timestamps and relative times.
+type TimeStyle int
+
+const (
+	TimeRelative TimeStyle = iota
+	TimeAbsolute
+)
+
+// FormatInstant shows t as a timestamp or as how long ago it happened.
+func FormatInstant(t, now time.Time, style TimeStyle) string {
+	if style == TimeAbsolute && !t.IsZero() {
+		return t.Format(time.DateTime)
+	}
+
+	return TimeAgo(t, now)
+}
+
+// FormatSince shows for how long something has been going on, or since when.
+func FormatSince(start, now time.Time, style TimeStyle) string {
+	if style == TimeAbsolute {
+		return "since " + start.Format(time.DateTime)
+	}
+
+	return HumanizeDuration(int64(now.Sub(start).Seconds()))
+}
@@ -0,0 +1,36 @@
+package app
+
+import "testing"
+
+func TestHumanizeDuration(t *testing.T) {
+	const (
+		minute = 60
+		hour   = 60 * minute
+		day    = 24 * hour
+		year   = 31557600
+	)
+	tests := []struct {
+		name    string
+		seconds int64
+		want    string
+	}{
+		{name: "zero", seconds: 0, want: "0s"},
+		{name: "under a minute", seconds: 59, want: "0s"},
+		{name: "negative", seconds: -5 * hour, want: "0s"},
+		{name: "most negative", seconds: -1 << 63, want: "0s"},
+		{name: "one minute", seconds: minute, want: "01m"},
+		{name: "mixed units", seconds: 2*day + 3*hour + 4*minute + 5, want: "02d 03h 04m"},
+		{name: "skipped unit", seconds: day + 30*minute, want: "01d 30m"},
+		{name: "exactly one year", seconds: year, want: "01y"},
+		{name: "more than a year", seconds: year + 2*day + hour, want: "01y 02d 01h"},
+		{name: "many years", seconds: 120 * year, want: "120y"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HumanizeDuration(tt.seconds); got != tt.want {
+				t.Errorf("HumanizeDuration(%d) = %q, want %q", tt.seconds, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+	"github.com/pdrolopes/syncthing_TUI/syncthing"
+	"github.com/samber/lo"
+)
+
+var folderIgnoresKeys = key.NewBinding(
+	key.WithKeys("X"),
+	key.WithHelp("X", "edit ignore patterns of the selected folder"),
+)
+
+type FetchedIgnoresMsg struct {
+	folderID string
+	ignores  syncthing.Ignores
+	err      error
+}
+
+// SavedIgnoresMsg ends a save like UserPostPutEndedMsg, it is shown in the
+// editor instead of the error banner.
+type SavedIgnoresMsg struct {
+	folderID string
+	err      error
+}
+
+func ignoresURL(httpData HttpData, folderID string) url.URL {
+	params := url.Values{}
+	params.Add("folder", folderID)
+	url := httpData.url.JoinPath(DB_IGNORES)
+	url.RawQuery = params.Encode()
+	return *url
+}
+
+func fetchIgnores(httpData HttpData, folderID string) tea.Cmd {
+	return func() tea.Msg {
+		var ignores syncthing.Ignores
+		err := fetchBytes(httpData, ignoresURL(httpData, folderID), &ignores)
+		return FetchedIgnoresMsg{folderID: folderID, ignores: ignores, err: err}
+	}
+}
+
+// postIgnores replaces the .stignore of the folder, syncthing rescans it after.
+func postIgnores(httpData HttpData, folderID string, lines []string) tea.Cmd {
+	return func() tea.Msg {
+		// syncthing decodes the body as lists only
+		data, err := json.Marshal(map[string][]string{"ignore": lines})
+		if err != nil {
+			return SavedIgnoresMsg{folderID: folderID, err: err}
+		}
+
+		url := ignoresURL(httpData, folderID)
+		req, err := http.NewRequest(http.MethodPost, url.String(), bytes.NewBuffer(data))
+		if err != nil {
+			return SavedIgnoresMsg{folderID: folderID, err: err}
+		}
+
+		httpData.authorize(req)
+		resp, err := httpData.client.Do(req)
+		if err != nil {
+			return SavedIgnoresMsg{folderID: folderID, err: err}
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			// syncthing explains invalid patterns in the body
+			body, _ := io.ReadAll(resp.Body)
+			return SavedIgnoresMsg{folderID: folderID, err: fmt.Errorf(
+				"saving ignore patterns failed with status %d: %s",
+				resp.StatusCode,
+				strings.TrimSpace(string(body)),
+			)}
+		}
+
+		return SavedIgnoresMsg{folderID: folderID}
+	}
+}
+
+// ignoreLines are the patterns of the editor, without the trailing blank lines.
+func ignoreLines(value string) []string {
+	lines := strings.Split(strings.TrimRight(value, "\n"), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		// an empty list, null would leave the old patterns
+		return []string{}
+	}
+	return lines
+}
+
+// FolderIgnoresModel edits the .stignore patterns of a folder.
+type FolderIgnoresModel struct {
+	Show        bool
+	folderID    string
+	folderLabel string
+	input       textarea.Model
+	loaded      bool
+	saving      bool
+	saved       bool
+	err         error
+	// syncthing couldn't parse the patterns it has
+	parseError string
+	zonePrefix string
+	httpData   HttpData
+}
+
+func NewFolderIgnores(folder FolderViewModel, httpData HttpData) FolderIgnoresModel {
+	input := textarea.New()
+	input.Placeholder = "Loading…"
+	input.ShowLineNumbers = false
+	input.MaxHeight = 0
+	input.SetWidth(56)
+	input.SetHeight(12)
+
+	return FolderIgnoresModel{
+		Show:        true,
+		folderID:    folder.Config.ID,
+		folderLabel: folderName(folder),
+		input:       input,
+		zonePrefix:  zone.NewPrefix(),
+		httpData:    httpData,
+	}
+}
+
+func (m FolderIgnoresModel) Init() tea.Cmd {
+	return fetchIgnores(m.httpData, m.folderID)
+}
+
+func (m FolderIgnoresModel) save() (FolderIgnoresModel, tea.Cmd) {
+	if !m.loaded || m.saving {
+		return m, nil
+	}
+
+	m.saving = true
+	m.saved = false
+	m.err = nil
+	return m, postIgnores(m.httpData, m.folderID, ignoreLines(m.input.Value()))
+}
+
+func (m FolderIgnoresModel) Update(msg tea.Msg) (FolderIgnoresModel, tea.Cmd) {
+	if !m.Show {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case FetchedIgnoresMsg:
+		if msg.folderID != m.folderID {
+			return m, nil
+		}
+		m.err = msg.err
+		if msg.err != nil {
+			return m, nil
+		}
+
+		m.loaded = true
+		m.parseError = msg.ignores.Error
+		m.input.Placeholder = "No ignore patterns, one pattern per line."
+		m.input.SetValue(strings.Join(msg.ignores.Ignore, "\n"))
+		return m, m.input.Focus()
+	case SavedIgnoresMsg:
+		if msg.folderID != m.folderID {
+			return m, nil
+		}
+		m.saving = false
+		m.err = msg.err
+		m.saved = msg.err == nil
+		if m.saved {
+			m.parseError = ""
+		}
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			m.Show = false
+			return m, nil
+		case "ctrl+s":
+			return m.save()
+		}
+		m.saved = false
+	case tea.MouseMsg:
+		if msg.Action != tea.MouseActionRelease || msg.Button != tea.MouseButtonLeft {
+			return m, nil
+		}
+
+		switch {
+		case zone.Get(m.zonePrefix + "save").InBounds(msg):
+			return m.save()
+		case zone.Get(m.zonePrefix+"close").InBounds(msg) ||
+			!zone.Get(m.zonePrefix+"area").InBounds(msg):
+			m.Show = false
+		}
+		return m, nil
+	}
+
+	if !m.loaded {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m FolderIgnoresModel) View() string {
+	const width = 60
+	header := lipgloss.NewStyle().
+		Padding(0, 1).
+		Width(width).
+		Background(styles.AccentColor).
+		Render(fmt.Sprintf("Ignore Patterns: %s", m.folderLabel))
+
+	rows := []string{
+		m.input.View(),
+		"",
+		lipgloss.NewStyle().Italic(true).Faint(true).
+			Render("One pattern per line, like in .stignore. [ctrl+s] saves, [esc] closes."),
+	}
+	var feedback string
+	switch {
+	case m.err != nil:
+		feedback = lipgloss.NewStyle().Foreground(styles.ErrorColor).Render("✗ " + m.err.Error())
+	case m.parseError != "":
+		feedback = lipgloss.NewStyle().Foreground(styles.WarningColor).Render("⚠ " + m.parseError)
+	case m.saving:
+		feedback = lipgloss.NewStyle().Faint(true).Render("Saving…")
+	case m.saved:
+		feedback = lipgloss.NewStyle().Foreground(styles.SuccessColor).Render("✓ Saved")
+	}
+	if feedback != "" {
+		rows = append(rows, "", feedback)
+	}
+
+	body := lipgloss.NewStyle().
+		Padding(1, 1).
+		Width(width).
+		Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+	saveBtn := lo.Ternary(m.loaded && !m.saving,
+		styles.BtnStyleV2.Render("Save"),
+		styles.BtnStyleV2.Faint(true).Render("Save"))
+	actions := lipgloss.PlaceHorizontal(width, lipgloss.Right, lipgloss.JoinHorizontal(lipgloss.Top,
+		zone.Mark(m.zonePrefix+"save", saveBtn),
+		"  ",
+		zone.Mark(m.zonePrefix+"close", styles.BtnStyleV2.Render("Close")),
+	))
+
+	return zone.Mark(
+		m.zonePrefix+"area",
+		lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Render(
+			lipgloss.JoinVertical(lipgloss.Left, header, body, actions),
+		),
+	)
+}
@@ -0,0 +1,143 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+)
+
+// FolderInviteModel shows what a peer needs to join a folder: this device ID to
+// add it as a remote device and the folder ID to accept the folder.
+type FolderInviteModel struct {
+	Show       bool
+	invite     string
+	copied     bool
+	zonePrefix string
+}
+
+func NewFolderInvite(folder FolderViewModel, thisDeviceID string) FolderInviteModel {
+	return FolderInviteModel{
+		Show:       true,
+		invite:     folderInvite(folder, thisDeviceID),
+		zonePrefix: zone.NewPrefix(),
+	}
+}
+
+func folderInvite(folder FolderViewModel, thisDeviceID string) string {
+	return fmt.Sprintf("Syncthing folder %q\nFolder ID: %s\nDevice ID: %s",
+		folderName(folder), folder.Config.ID, thisDeviceID)
+}
+
+// the OSC 52 sequence stays in the view long enough to be rendered once
+const CLIPBOARD_HOLD = 200 * time.Millisecond
+
+// CopyToClipboardMsg asks the model to copy text with the OSC 52 escape sequence,
+// so it also works over ssh when the terminal supports it. The sequence goes out
+// with the view, writing it from a command would interleave with the renderer.
+type CopyToClipboardMsg struct {
+	text string
+}
+
+// ClipboardWrittenMsg takes the sequence of text out of the view again.
+type ClipboardWrittenMsg struct {
+	text string
+}
+
+func copyToClipboard(text string) tea.Cmd {
+	return func() tea.Msg {
+		return CopyToClipboardMsg{text: text}
+	}
+}
+
+func clipboardWritten(text string) tea.Cmd {
+	return tea.Tick(CLIPBOARD_HOLD, func(time.Time) tea.Msg {
+		return ClipboardWrittenMsg{text: text}
+	})
+}
+
+// viewClipboard is the zero width sequence setting the clipboard to text.
+func viewClipboard(text string) string {
+	if text == "" {
+		return ""
+	}
+
+	return ansi.SetSystemClipboard(text)
+}
+
+func (m FolderInviteModel) copy() (FolderInviteModel, tea.Cmd) {
+	m.copied = true
+	return m, copyToClipboard(m.invite)
+}
+
+func (m FolderInviteModel) Update(msg tea.Msg) (FolderInviteModel, tea.Cmd) {
+	if !m.Show {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q", "I":
+			m.Show = false
+		case "enter", "y":
+			return m.copy()
+		}
+	case tea.MouseMsg:
+		if msg.Action != tea.MouseActionRelease || msg.Button != tea.MouseButtonLeft {
+			return m, nil
+		}
+
+		if zone.Get(m.zonePrefix + "copy").InBounds(msg) {
+			return m.copy()
+		}
+
+		if zone.Get(m.zonePrefix+"close").InBounds(msg) ||
+			!zone.Get(m.zonePrefix+"area").InBounds(msg) {
+			m.Show = false
+		}
+	}
+
+	return m, nil
+}
+
+func (m FolderInviteModel) View() string {
+	const width = 80
+	header := lipgloss.NewStyle().
+		Padding(0, 1).
+		Width(width).
+		Background(styles.AccentColor).
+		Render("Folder Invite")
+
+	rows := []string{
+		"Send this to the peer. They add the device ID as a remote device, " +
+			"then accept the folder when it is offered or add it with the same folder ID.",
+		"",
+		lipgloss.NewStyle().Bold(true).Render(m.invite),
+	}
+	if m.copied {
+		rows = append(rows, "", lipgloss.NewStyle().Italic(true).
+			Render("Copied, if the terminal allows clipboard access."))
+	}
+
+	body := lipgloss.NewStyle().
+		Padding(1, 1).
+		Width(width).
+		Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+	actions := lipgloss.PlaceHorizontal(width, lipgloss.Right, lipgloss.JoinHorizontal(lipgloss.Top,
+		zone.Mark(m.zonePrefix+"copy", styles.BtnStyleV2.Render("Copy")),
+		"  ",
+		zone.Mark(m.zonePrefix+"close", styles.BtnStyleV2.Render("Close")),
+	))
+
+	return zone.Mark(
+		m.zonePrefix+"area",
+		lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Render(
+			lipgloss.JoinVertical(lipgloss.Left, header, body, actions),
+		),
+	)
+}
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pdrolopes/syncthing_TUI/syncthing"
+)
+
+func TestCopyInvite(t *testing.T) {
+	folder := FolderViewModel{Config: syncthing.FolderConfig{ID: "default"}}
+	invite := NewFolderInvite(folder, "LOCAL")
+	seq := ansi.SetSystemClipboard(invite.invite)
+	tests := []struct {
+		name    string
+		written []ClipboardWrittenMsg
+		wantSeq bool
+	}{
+		{name: "copied", wantSeq: true},
+		{name: "rendered", written: []ClipboardWrittenMsg{{text: invite.invite}}},
+		{
+			name:    "an older copy rendered",
+			written: []ClipboardWrittenMsg{{text: "old"}},
+			wantSeq: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewModel(Options{})
+			var cmd tea.Cmd
+			m.folderInviteModal, cmd = invite.copy()
+			m = updateAll(m, cmd())
+			for _, msg := range tt.written {
+				m = updateAll(m, msg)
+			}
+
+			if got := strings.HasPrefix(m.View(), seq); got != tt.wantSeq {
+				t.Errorf("View() starts with the clipboard sequence = %v, want %v",
+					got, tt.wantSeq)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// LabelEditor renames a folder from the header of its card.
+type LabelEditor struct {
+	// folder being renamed, empty when not editing
+	FolderID string
+	input    textinput.Model
+}
+
+func NewLabelEditor(folder FolderViewModel) LabelEditor {
+	input := textinput.New()
+	input.Placeholder = folder.Config.ID
+	input.CharLimit = 100
+	input.Width = 30
+	input.SetValue(folder.Config.Label)
+	input.Focus()
+
+	return LabelEditor{FolderID: folder.Config.ID, input: input}
+}
+
+func (e LabelEditor) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (e LabelEditor) Active() bool {
+	return e.FolderID != ""
+}
+
+func (e LabelEditor) Update(msg tea.Msg, httpData HttpData) (LabelEditor, tea.Cmd) {
+	if !e.Active() {
+		return e, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.Type {
+		case tea.KeyEsc:
+			return LabelEditor{}, nil
+		case tea.KeyEnter:
+			// syncthing shows the folder ID when the label is empty
+			label := strings.TrimSpace(e.input.Value())
+			return LabelEditor{}, updateFolderLabel(httpData, e.FolderID, label)
+		}
+	}
+
+	var cmd tea.Cmd
+	e.input, cmd = e.input.Update(msg)
+	return e, cmd
+}
+
+func (e LabelEditor) View() string {
+	return e.input.View()
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+)
+
+const (
+	LOADING_RETRY_MIN = time.Second
+	LOADING_RETRY_MAX = 30 * time.Second
+)
+
+// Loading tracks the startup until the first config and system status arrive.
+// Failed attempts are retried with a backoff instead of showing the error screen.
+type Loading struct {
+	configLoaded bool
+	statusLoaded bool
+	// failed system status fetches, they pace the retries of both requests
+	Attempts int
+	LastErr  error
+	RetryAt  time.Time
+}
+
+func (l Loading) Done() bool {
+	return l.configLoaded && l.statusLoaded
+}
+
+// RetryDelay doubles with every failed attempt, up to LOADING_RETRY_MAX.
+func (l Loading) RetryDelay() time.Duration {
+	return backoffDelay(l.Attempts, LOADING_RETRY_MIN, LOADING_RETRY_MAX)
+}
+
+// backoffDelay starts at minDelay for the first failed attempt and doubles with
+// every following one, up to maxDelay.
+func backoffDelay(attempts int, minDelay, maxDelay time.Duration) time.Duration {
+	delay := minDelay
+	for i := 1; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > maxDelay {
+		return maxDelay
+	}
+
+	return delay
+}
+
+func (l Loading) failed(err error, currentTime time.Time) Loading {
+	l.Attempts++
+	l.LastErr = err
+	l.RetryAt = currentTime.Add(l.RetryDelay())
+	return l
+}
+
+func viewLoading(
+	loading Loading,
+	httpData HttpData,
+	currentTime time.Time,
+	width, height int,
+) string {
+	rows := []string{
+		lipgloss.NewStyle().Bold(true).Render(
+			fmt.Sprintf("Connecting to Syncthing at %s…", httpData.url.Redacted())),
+	}
+	if loading.LastErr != nil {
+		retry := "retrying now"
+		if wait := loading.RetryAt.Sub(currentTime).Round(time.Second); wait > 0 {
+			retry = fmt.Sprintf("retrying in %s", wait)
+		}
+		rows = append(rows,
+			"",
+			lipgloss.NewStyle().Foreground(styles.WarningColor).
+				Render(fmt.Sprintf("Attempt %d failed, %s", loading.Attempts, retry)),
+			lipgloss.NewStyle().Faint(true).Render(loading.LastErr.Error()),
+		)
+	}
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center,
+		lipgloss.NewStyle().Width(min(width, 80)).Align(lipgloss.Center).
+			Render(lipgloss.JoinVertical(lipgloss.Center, rows...)))
+}
+
+// finishLoading switches to the dashboard once the minimal data is present.
+func (m model) finishLoading() (model, tea.Cmd) {
+	if m.loaded || !m.loading.Done() {
+		return m, nil
+	}
+
+	m.loaded = true
+	return m, m.fetchDashboard()
+}
@@ -0,0 +1,228 @@
+package app
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+	"github.com/pdrolopes/syncthing_TUI/syncthing"
+	"github.com/samber/lo"
+)
+
+const (
+	LOG_REFRESH_INTERVAL = 2 * time.Second
+	// syncthing keeps a few hundred lines, older ones are dropped here as well
+	LOG_LINES_LIMIT = 1000
+)
+
+var systemLogKeys = key.NewBinding(
+	key.WithKeys("V"),
+	key.WithHelp("V", "view the syncthing log"),
+)
+
+type FetchedSystemLogMsg struct {
+	// zone prefix of the viewer that asked, a closed viewer ignores its last fetch
+	viewer string
+	log    syncthing.SystemLog
+	err    error
+}
+
+func fetchSystemLog(httpData HttpData, viewer string, since time.Time) tea.Cmd {
+	return func() tea.Msg {
+		params := url.Values{}
+		if !since.IsZero() {
+			params.Add("since", since.Format(time.RFC3339Nano))
+		}
+		url := httpData.url.JoinPath(SYSTEM_LOG)
+		url.RawQuery = params.Encode()
+		var log syncthing.SystemLog
+		err := fetchBytes(httpData, *url, &log)
+
+		return FetchedSystemLogMsg{viewer: viewer, log: log, err: err}
+	}
+}
+
+// LogViewerModel shows the recent syncthing log lines, refreshed while open.
+type LogViewerModel struct {
+	Show     bool
+	lines    []syncthing.LogLine
+	err      error
+	viewport viewport.Model
+	// keeps the newest lines in view as they arrive
+	follow     bool
+	zonePrefix string
+	httpData   HttpData
+}
+
+// logViewerSize leaves room around the modal for its border and the dashboard.
+func logViewerSize(terminalWidth, terminalHeight int) (int, int) {
+	return max(40, terminalWidth-8), max(5, terminalHeight-8)
+}
+
+func NewLogViewer(httpData HttpData, width, height int) LogViewerModel {
+	return LogViewerModel{
+		Show:       true,
+		viewport:   viewport.New(width, height),
+		follow:     true,
+		zonePrefix: zone.NewPrefix(),
+		httpData:   httpData,
+	}
+}
+
+func (m LogViewerModel) Init() tea.Cmd {
+	return fetchSystemLog(m.httpData, m.zonePrefix, time.Time{})
+}
+
+func (m LogViewerModel) Resize(width, height int) LogViewerModel {
+	m.viewport.Width = width
+	m.viewport.Height = height
+	m.viewport.SetContent(m.content())
+	return m
+}
+
+func (m LogViewerModel) since() time.Time {
+	if len(m.lines) == 0 {
+		return time.Time{}
+	}
+
+	return m.lines[len(m.lines)-1].When
+}
+
+// refresh fetches the lines logged after the ones already shown.
+func (m LogViewerModel) refresh() tea.Cmd {
+	return wait(LOG_REFRESH_INTERVAL, fetchSystemLog(m.httpData, m.zonePrefix, m.since()))
+}
+
+func (m LogViewerModel) content() string {
+	if len(m.lines) == 0 {
+		return lipgloss.NewStyle().Italic(true).Faint(true).Render("No log lines yet.")
+	}
+
+	lines := make([]string, len(m.lines))
+	for i, line := range m.lines {
+		text := fmt.Sprintf("%s %s", line.When.Local().Format(time.TimeOnly), line.Message)
+		switch {
+		case line.Level >= syncthing.LogLevelError:
+			text = lipgloss.NewStyle().Foreground(styles.ErrorColor).Render(text)
+		case line.Level == syncthing.LogLevelWarn:
+			text = lipgloss.NewStyle().Foreground(styles.WarningColor).Render(text)
+		case line.Level < syncthing.LogLevelInfo:
+			text = lipgloss.NewStyle().Faint(true).Render(text)
+		}
+		lines[i] = lipgloss.NewStyle().Width(m.viewport.Width).Render(text)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (m LogViewerModel) Update(msg tea.Msg) (LogViewerModel, tea.Cmd) {
+	if !m.Show {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case FetchedSystemLogMsg:
+		if msg.viewer != m.zonePrefix {
+			return m, nil
+		}
+		m.err = msg.err
+		if msg.err != nil {
+			return m, m.refresh()
+		}
+
+		m.lines = append(m.lines, msg.log.Messages...)
+		if len(m.lines) > LOG_LINES_LIMIT {
+			m.lines = m.lines[len(m.lines)-LOG_LINES_LIMIT:]
+		}
+		m.viewport.SetContent(m.content())
+		if m.follow {
+			m.viewport.GotoBottom()
+		}
+		return m, m.refresh()
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q", "V":
+			m.Show = false
+		case "f":
+			m.follow = !m.follow
+			if m.follow {
+				m.viewport.GotoBottom()
+			}
+		case "up", "k":
+			m.viewport.ScrollUp(1)
+			m.follow = false
+		case "down", "j":
+			m.viewport.ScrollDown(1)
+			m.follow = m.viewport.AtBottom()
+		case "pgup":
+			m.viewport.PageUp()
+			m.follow = false
+		case "pgdown":
+			m.viewport.PageDown()
+			m.follow = m.viewport.AtBottom()
+		case "g", "home":
+			m.viewport.GotoTop()
+			m.follow = false
+		case "G", "end":
+			m.viewport.GotoBottom()
+			m.follow = true
+		}
+	case tea.MouseMsg:
+		switch {
+		case msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonWheelUp:
+			m.viewport.ScrollUp(SCROLL_WHEEL_LINES)
+			m.follow = false
+		case msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonWheelDown:
+			m.viewport.ScrollDown(SCROLL_WHEEL_LINES)
+			m.follow = m.viewport.AtBottom()
+		case msg.Action != tea.MouseActionRelease || msg.Button != tea.MouseButtonLeft:
+		case zone.Get(m.zonePrefix + "follow").InBounds(msg):
+			m.follow = !m.follow
+			if m.follow {
+				m.viewport.GotoBottom()
+			}
+		case zone.Get(m.zonePrefix+"close").InBounds(msg) ||
+			!zone.Get(m.zonePrefix+"area").InBounds(msg):
+			m.Show = false
+		}
+	}
+
+	return m, nil
+}
+
+func (m LogViewerModel) View() string {
+	width := m.viewport.Width
+	header := lipgloss.NewStyle().
+		Padding(0, 1).
+		Width(width).
+		Background(styles.AccentColor).
+		Render("Syncthing Log")
+
+	rows := []string{m.viewport.View()}
+	if m.err != nil {
+		rows = append(rows, lipgloss.NewStyle().Foreground(styles.ErrorColor).
+			Render("✗ "+m.err.Error()))
+	}
+	help := lipgloss.NewStyle().Faint(true).Render("↑/↓ pgup/pgdown g/G scroll, f follow")
+	actions := viewFooter(width, []string{help}, []string{
+		zone.Mark(m.zonePrefix+"follow",
+			styles.BtnStyleV2.Render(lo.Ternary(m.follow, "Following", "Follow"))),
+		" ",
+		zone.Mark(m.zonePrefix+"close", styles.BtnStyleV2.Render("Close")),
+	})
+
+	views := append(append([]string{header}, rows...), actions)
+	return zone.Mark(
+		m.zonePrefix+"area",
+		lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Render(
+			lipgloss.JoinVertical(lipgloss.Left, views...),
+		),
+	)
+}
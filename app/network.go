@@ -0,0 +1,74 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss/table"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+	"github.com/pdrolopes/syncthing_TUI/syncthing"
+	"github.com/samber/lo"
+)
+
+const NETWORK_TOGGLE_MARK_PREFIX = "network-toggle/"
+
+// networkToggle is an on/off syncthing option shown in the status panel.
+type networkToggle struct {
+	name  string
+	label string
+	get   func(options syncthing.Options) bool
+	set   func(options *syncthing.Options, enabled bool)
+}
+
+func (t networkToggle) Mark() string {
+	return NETWORK_TOGGLE_MARK_PREFIX + t.name
+}
+
+var networkToggles = []networkToggle{
+	{
+		name:  "global-discovery",
+		label: "Global Discovery",
+		get:   func(o syncthing.Options) bool { return o.GlobalAnnounceEnabled },
+		set:   func(o *syncthing.Options, enabled bool) { o.GlobalAnnounceEnabled = enabled },
+	},
+	{
+		name:  "local-discovery",
+		label: "Local Discovery",
+		get:   func(o syncthing.Options) bool { return o.LocalAnnounceEnabled },
+		set:   func(o *syncthing.Options, enabled bool) { o.LocalAnnounceEnabled = enabled },
+	},
+	{
+		name:  "relays",
+		label: "Relays",
+		get:   func(o syncthing.Options) bool { return o.RelaysEnabled },
+		set:   func(o *syncthing.Options, enabled bool) { o.RelaysEnabled = enabled },
+	},
+	{
+		name:  "nat",
+		label: "NAT Traversal",
+		get:   func(o syncthing.Options) bool { return o.NatEnabled },
+		set:   func(o *syncthing.Options, enabled bool) { o.NatEnabled = enabled },
+	},
+}
+
+func viewNetworkToggles(t *table.Table, options syncthing.Options) *table.Table {
+	for _, toggle := range networkToggles {
+		label := lo.Ternary(toggle.get(options), "Enabled", "Disabled")
+		t = t.Row(toggle.label, zone.Mark(toggle.Mark(), styles.BtnStyleV2.Render(label)))
+	}
+
+	return t
+}
+
+// toggleNetworkOption flips the option in the syncthing config, the panel shows
+// the new value once syncthing sends the saved config back.
+func (m model) toggleNetworkOption(toggle networkToggle) (model, tea.Cmd) {
+	if m.putConfig == nil {
+		return m, nil
+	}
+
+	enabled := !toggle.get(m.thisDeviceStatus.Options)
+	return m, m.putConfig(m.httpData, func(config syncthing.Config) syncthing.Config {
+		toggle.set(&config.Options, enabled)
+		return config
+	})
+}
@@ -0,0 +1,31 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Notify rings the terminal bell and sends a desktop notification through the
+// OSC 9 and OSC 777 escape sequences. Terminals without support ignore them.
+func Notify(w io.Writer, title, body string) error {
+	title, body = notificationText(title), notificationText(body)
+	_, err := fmt.Fprintf(w, "\a\x1b]9;%s: %s\a\x1b]777;notify;%s;%s\a", title, body, title, body)
+	return err
+}
+
+// notificationText strips the characters that would end the escape sequence.
+func notificationText(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\a' || r == '\x1b' || r == ';' {
+			return ' '
+		}
+		return r
+	}, text)
+}
+
+// TestNotify sends a sample notification, so users can check their terminal
+// shows it before relying on it.
+func TestNotify(w io.Writer) error {
+	return Notify(w, "syncthing TUI", "Test notification")
+}
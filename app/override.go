@@ -0,0 +1,97 @@
+package app
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+)
+
+const (
+	OVERRIDE_CHANGES_MODAL_AREA  = "override-changes-modal"
+	OVERRIDE_CHANGES_CONFIRM_BTN = "confirm-override-changes"
+	OVERRIDE_CHANGES_CANCEL_BTN  = "cancel-override-changes"
+)
+
+// ConfirmOverrideChanges is the send only mirror of ConfirmRevertLocalAdditions.
+type ConfirmOverrideChanges struct {
+	Show     bool
+	folderID string
+}
+
+// overridable is a send only folder with changes made elsewhere, overriding them
+// pushes the local state back to the other devices.
+func overridable(folder FolderViewModel) bool {
+	return folder.Config.Type == "sendonly" && folderStatus(folder) == OutOfSync
+}
+
+func viewConfirmOverrideChangesFolder() string {
+	const width = 60
+	header := lipgloss.NewStyle().
+		Padding(1, 1).
+		Width(width).
+		Background(styles.ErrorColor).
+		Render("Override Changes")
+	body := lipgloss.NewStyle().Padding(1, 1).Width(width).Render("Warning!\n\n" +
+		"The folder content on other devices will be overwritten to become identical " +
+		"with this device. Changes made on other devices will be lost.\n\n" +
+		"Are you sure you want to override all remote changes? (y/n)\n")
+	var actions string
+	{
+		layout := lipgloss.NewStyle().Padding(0, 1).Width(width)
+		btnConfirm := zone.Mark(
+			OVERRIDE_CHANGES_CONFIRM_BTN,
+			styles.NegativeBtn.Render("Override"),
+		)
+		btnCancel := zone.Mark(OVERRIDE_CHANGES_CANCEL_BTN, styles.BtnStyleV2.Render("Cancel"))
+		gap := strings.Repeat(" ", max(0, layout.GetWidth()-layout.GetHorizontalPadding()-
+			lipgloss.Width(btnConfirm)-lipgloss.Width(btnCancel)))
+		actions = layout.Render(lipgloss.JoinHorizontal(lipgloss.Top, btnConfirm, gap, btnCancel))
+	}
+
+	return zone.Mark(
+		OVERRIDE_CHANGES_MODAL_AREA,
+		lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Render(
+			lipgloss.JoinVertical(lipgloss.Left, header, body, actions),
+		),
+	)
+}
+
+func handleMouseEventsOverrideModal(m model, msg tea.MouseMsg) (model, tea.Cmd) {
+	if msg.Action != tea.MouseActionRelease || msg.Button != tea.MouseButtonLeft {
+		return m, nil
+	}
+
+	switch {
+	case zone.Get(OVERRIDE_CHANGES_CONFIRM_BTN).InBounds(msg):
+		return confirmOverrideModal(m)
+	case zone.Get(OVERRIDE_CHANGES_CANCEL_BTN).InBounds(msg) ||
+		!zone.Get(OVERRIDE_CHANGES_MODAL_AREA).InBounds(msg):
+		m.confirmOverrideChangesModal = ConfirmOverrideChanges{}
+	}
+
+	return m, nil
+}
+
+// handleKeyBoardEventsOverrideModal swallows every other key, like the revert
+// modal does.
+func handleKeyBoardEventsOverrideModal(m model, msg tea.KeyMsg) (model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "enter":
+		return confirmOverrideModal(m)
+	case "esc", "n":
+		m.confirmOverrideChangesModal = ConfirmOverrideChanges{}
+	case "q", "ctrl+c", "ctrl+d":
+		return m, tea.Quit
+	}
+
+	return m, nil
+}
+
+func confirmOverrideModal(m model) (model, tea.Cmd) {
+	folderID := m.confirmOverrideChangesModal.folderID
+	m.confirmOverrideChangesModal = ConfirmOverrideChanges{}
+	return m, postOverrideChanges(m.httpData, folderID)
+}
@@ -0,0 +1,196 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+	"github.com/samber/lo"
+)
+
+const PAUSED_FOLDERS_MARK = "paused-folders"
+
+var pausedFoldersKeys = key.NewBinding(
+	key.WithKeys("u"),
+	key.WithHelp("u", "pick paused folders to resume"),
+)
+
+// ResumeFoldersMsg asks the main model to resume the folders, so they are marked
+// as pending like with Resume All.
+type ResumeFoldersMsg struct {
+	folderIDs []string
+}
+
+type pausedFolder struct {
+	ID    string
+	Label string
+	// paused with Pause All rather than one by one
+	Bulk bool
+}
+
+// PausedFoldersModel lists the paused folders to resume only some of them. The
+// ones paused with Pause All start selected, the ones paused on purpose don't.
+type PausedFoldersModel struct {
+	Show       bool
+	folders    []pausedFolder
+	selected   map[string]struct{}
+	cursor     int
+	zonePrefix string
+}
+
+func NewPausedFolders(folders []FolderViewModel, bulkPaused []string) PausedFoldersModel {
+	m := PausedFoldersModel{
+		selected:   make(map[string]struct{}),
+		zonePrefix: zone.NewPrefix(),
+	}
+	for _, f := range folders {
+		if !f.Config.Paused {
+			continue
+		}
+		bulk := lo.Contains(bulkPaused, f.Config.ID)
+		m.folders = append(m.folders, pausedFolder{
+			ID:    f.Config.ID,
+			Label: folderName(f),
+			Bulk:  bulk,
+		})
+		if bulk {
+			m.selected[f.Config.ID] = struct{}{}
+		}
+	}
+	m.Show = len(m.folders) > 0
+
+	return m
+}
+
+func (m PausedFoldersModel) rowMark(i int) string {
+	return fmt.Sprintf("%srow-%d", m.zonePrefix, i)
+}
+
+func (m PausedFoldersModel) toggle(folderID string) PausedFoldersModel {
+	if _, ok := m.selected[folderID]; ok {
+		delete(m.selected, folderID)
+	} else {
+		m.selected[folderID] = struct{}{}
+	}
+	return m
+}
+
+func (m PausedFoldersModel) toggleAll() PausedFoldersModel {
+	if len(m.selected) == len(m.folders) {
+		m.selected = make(map[string]struct{})
+		return m
+	}
+	for _, f := range m.folders {
+		m.selected[f.ID] = struct{}{}
+	}
+	return m
+}
+
+func (m PausedFoldersModel) resume() (PausedFoldersModel, tea.Cmd) {
+	if len(m.selected) == 0 {
+		return m, nil
+	}
+
+	// keep the listed order
+	folderIDs := lo.FilterMap(m.folders, func(f pausedFolder, index int) (string, bool) {
+		_, ok := m.selected[f.ID]
+		return f.ID, ok
+	})
+	m.Show = false
+	return m, func() tea.Msg { return ResumeFoldersMsg{folderIDs: folderIDs} }
+}
+
+func (m PausedFoldersModel) Update(msg tea.Msg) (PausedFoldersModel, tea.Cmd) {
+	if !m.Show {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q", "u":
+			m.Show = false
+		case "up", "k":
+			m.cursor = max(0, m.cursor-1)
+		case "down", "j":
+			m.cursor = min(len(m.folders)-1, m.cursor+1)
+		case " ", "x":
+			m = m.toggle(m.folders[m.cursor].ID)
+		case "a":
+			m = m.toggleAll()
+		case "enter":
+			return m.resume()
+		}
+	case tea.MouseMsg:
+		if msg.Action != tea.MouseActionRelease || msg.Button != tea.MouseButtonLeft {
+			return m, nil
+		}
+
+		for i, f := range m.folders {
+			if zone.Get(m.rowMark(i)).InBounds(msg) {
+				m.cursor = i
+				return m.toggle(f.ID), nil
+			}
+		}
+
+		switch {
+		case zone.Get(m.zonePrefix + "all").InBounds(msg):
+			m = m.toggleAll()
+		case zone.Get(m.zonePrefix + "resume").InBounds(msg):
+			return m.resume()
+		case zone.Get(m.zonePrefix+"close").InBounds(msg) ||
+			!zone.Get(m.zonePrefix+"area").InBounds(msg):
+			m.Show = false
+		}
+	}
+
+	return m, nil
+}
+
+func (m PausedFoldersModel) View() string {
+	const width = 60
+	header := lipgloss.NewStyle().
+		Padding(0, 1).
+		Width(width).
+		Background(styles.AccentColor).
+		Render("Paused Folders")
+
+	detail := lipgloss.NewStyle().Faint(true)
+	rows := lo.Map(m.folders, func(f pausedFolder, i int) string {
+		_, selected := m.selected[f.ID]
+		row := fmt.Sprintf("%s %s", lo.Ternary(selected, "[x]", "[ ]"), f.Label)
+		if i == m.cursor {
+			row = lipgloss.NewStyle().Reverse(true).Render(row)
+		}
+		row += " " + detail.Render(lo.Ternary(f.Bulk, "paused with Pause All", "paused manually"))
+		return zone.Mark(m.rowMark(i), row)
+	})
+	rows = append(rows, "", detail.Render("↑/↓ move, space select, a all, enter resume"))
+	body := lipgloss.NewStyle().
+		Padding(1, 1).
+		Width(width).
+		Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+
+	resumeBtn := styles.BtnStyleV2.Render(fmt.Sprintf("Resume Selected (%d)", len(m.selected)))
+	if len(m.selected) == 0 {
+		resumeBtn = styles.BtnStyleV2.Faint(true).Render("Resume Selected")
+	}
+	btns := []string{
+		zone.Mark(m.zonePrefix+"all", styles.BtnStyleV2.Render("All")),
+		" ",
+		zone.Mark(m.zonePrefix+"resume", resumeBtn),
+		" ",
+		zone.Mark(m.zonePrefix+"close", styles.BtnStyleV2.Render("Close")),
+	}
+	actions := lipgloss.NewStyle().Padding(0, 1).Render(viewFooter(width-2, nil, btns))
+
+	return zone.Mark(
+		m.zonePrefix+"area",
+		lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Render(
+			lipgloss.JoinVertical(lipgloss.Left, header, body, actions),
+		),
+	)
+}
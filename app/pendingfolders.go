@@ -0,0 +1,169 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+	"github.com/pdrolopes/syncthing_TUI/syncthing"
+	"github.com/samber/lo"
+)
+
+// PendingFolder is a folder a remote device offers to share. A folder offered
+// by several devices has one entry per device.
+type PendingFolder struct {
+	FolderID         string
+	Label            string
+	DeviceID         string
+	ReceiveEncrypted bool
+	At               time.Time
+}
+
+func pendingFolderKey(folderID, deviceID string) string {
+	return deviceID + "/" + folderID
+}
+
+func (pf PendingFolder) Key() string {
+	return pendingFolderKey(pf.FolderID, pf.DeviceID)
+}
+
+func (pf PendingFolder) DismissMark() string {
+	return pf.Key() + "/dismiss-folder"
+}
+
+func (pf PendingFolder) IgnoreMark() string {
+	return pf.Key() + "/ignore-folder"
+}
+
+func (pf PendingFolder) AddMark() string {
+	return pf.Key() + "/add-folder"
+}
+
+// PendingFolderByRecent sorts the latest offers first.
+type PendingFolderByRecent []PendingFolder
+
+func (list PendingFolderByRecent) Len() int           { return len(list) }
+func (list PendingFolderByRecent) Swap(i, j int)      { list[i], list[j] = list[j], list[i] }
+func (list PendingFolderByRecent) Less(i, j int) bool { return list[i].At.After(list[j].At) }
+
+type FetchedPendingFolders struct {
+	err     error
+	folders map[string]syncthing.PendingFolderInfo
+}
+
+func pendingFoldersFromInfo(
+	folders map[string]syncthing.PendingFolderInfo,
+) map[string]PendingFolder {
+	pending := make(map[string]PendingFolder)
+	for folderID, info := range folders {
+		for deviceID, offer := range info.OfferedBy {
+			pending[pendingFolderKey(folderID, deviceID)] = PendingFolder{
+				FolderID:         folderID,
+				Label:            offer.Label,
+				DeviceID:         deviceID,
+				ReceiveEncrypted: offer.ReceiveEncrypted,
+				At:               offer.Time,
+			}
+		}
+	}
+
+	return pending
+}
+
+// ignorePendingFolder stops the device from offering the folder again.
+func ignorePendingFolder(
+	config syncthing.Config,
+	folder PendingFolder,
+	currentTime time.Time,
+) syncthing.Config {
+	devices := make([]syncthing.DeviceConfig, len(config.Devices))
+	for i, device := range config.Devices {
+		if device.DeviceID == folder.DeviceID {
+			device.IgnoredFolders = append(
+				append([]syncthing.IgnoredFolder{}, device.IgnoredFolders...),
+				syncthing.IgnoredFolder{
+					Time:  currentTime,
+					ID:    folder.FolderID,
+					Label: folder.Label,
+				},
+			)
+		}
+		devices[i] = device
+	}
+	config.Devices = devices
+
+	return config
+}
+
+func viewPendingFolders(
+	pendingFolders []PendingFolder,
+	devices []DeviceViewModel,
+	currentTime time.Time,
+	timeStyle TimeStyle,
+) string {
+	if len(pendingFolders) == 0 {
+		return ""
+	}
+	const width = 80
+	container := lipgloss.
+		NewStyle().
+		Border(lipgloss.RoundedBorder(), true).
+		Padding(0, 1)
+
+	headerStyle := lipgloss.
+		NewStyle().
+		Width(container.GetWidth()-container.GetHorizontalPadding()).
+		Background(styles.WarningColor).
+		Padding(0, 1).
+		Foreground(lipgloss.Color("#ffffff"))
+
+	descriptionStyle := lipgloss.
+		NewStyle().
+		Width(width - 2)
+	views := make([]string, 0, len(pendingFolders))
+	for _, p := range pendingFolders {
+		header := headerStyle.Render(
+			spaceAroundTable().Width(width-headerStyle.GetHorizontalPadding()).Row(
+				"New Folder",
+				FormatInstant(p.At, currentTime, timeStyle),
+			).Render(),
+		)
+
+		deviceName := shortIdentification(p.DeviceID)
+		if device, found := lo.Find(devices, func(d DeviceViewModel) bool {
+			return d.Config.DeviceID == p.DeviceID
+		}); found && device.Config.Name != "" {
+			deviceName = device.Config.Name
+		}
+		description := fmt.Sprintf(
+			"Device \"%s\" wants to share folder \"%s\" (%s). Add new folder?",
+			deviceName,
+			lo.Ternary(p.Label != "", p.Label, p.FolderID),
+			p.FolderID,
+		)
+		if p.ReceiveEncrypted {
+			description += " It is offered encrypted, " +
+				"this device would only store the encrypted data."
+		}
+		btns := lipgloss.JoinHorizontal(lipgloss.Top,
+			zone.Mark(p.AddMark(), styles.PositiveBtn.Render("Add Folder")),
+			" ",
+			zone.Mark(p.IgnoreMark(), styles.NegativeBtn.Render("Ignore")),
+			" ",
+			zone.Mark(p.DismissMark(), styles.BtnStyleV2.Render("Dismiss")),
+		)
+
+		views = append(views, container.Render(lipgloss.JoinVertical(lipgloss.Left,
+			header,
+			"",
+			descriptionStyle.Render(description),
+			"",
+			lipgloss.PlaceHorizontal(width, lipgloss.Right, btns),
+		)))
+		views = append(views, "")
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, views...)
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pdrolopes/syncthing_TUI/syncthing"
+)
+
+type CheckedFolderFilesystemsMsg struct {
+	// folders living on a filesystem that doesn't keep unix permissions
+	withoutPermissions map[string]struct{}
+}
+
+// checkFolderFilesystems looks for folders on filesystems like FAT or SMB where
+// syncthing should ignore permissions. It only works when syncthing runs on this
+// machine, remote folder paths can't be inspected.
+func checkFolderFilesystems(httpData HttpData, folders []syncthing.FolderConfig) tea.Cmd {
+	if !isLocalURL(httpData.url) {
+		return nil
+	}
+
+	return func() tea.Msg {
+		withoutPermissions := make(map[string]struct{})
+		for _, folder := range folders {
+			keeps, known := fsKeepsPermissions(expandHome(folder.Path))
+			if known && !keeps {
+				withoutPermissions[folder.ID] = struct{}{}
+			}
+		}
+
+		return CheckedFolderFilesystemsMsg{withoutPermissions: withoutPermissions}
+	}
+}
+
+func isLocalURL(u url.URL) bool {
+	host := u.Hostname()
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
@@ -0,0 +1,27 @@
+//go:build darwin
+
+package app
+
+import "syscall"
+
+func fsKeepsPermissions(path string) (keeps bool, known bool) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return false, false
+	}
+
+	name := make([]byte, 0, len(stat.Fstypename))
+	for _, c := range stat.Fstypename {
+		if c == 0 {
+			break
+		}
+		name = append(name, byte(c))
+	}
+
+	switch string(name) {
+	case "msdos", "exfat", "smbfs":
+		return false, true
+	}
+
+	return true, true
+}
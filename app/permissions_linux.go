@@ -0,0 +1,30 @@
+//go:build linux
+
+package app
+
+import "syscall"
+
+// filesystem magic numbers from statfs(2)
+const (
+	MSDOS_SUPER_MAGIC = 0x4d44
+	EXFAT_SUPER_MAGIC = 0x2011bab0
+	SMB_SUPER_MAGIC   = 0x517b
+	CIFS_MAGIC_NUMBER = 0xff534d42
+	SMB2_MAGIC_NUMBER = 0xfe534d42
+)
+
+func fsKeepsPermissions(path string) (keeps bool, known bool) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return false, false
+	}
+
+	// the field width changes between architectures, magic numbers fit in 32 bits
+	switch uint32(stat.Type) {
+	case MSDOS_SUPER_MAGIC, EXFAT_SUPER_MAGIC,
+		SMB_SUPER_MAGIC, CIFS_MAGIC_NUMBER, SMB2_MAGIC_NUMBER:
+		return false, true
+	}
+
+	return true, true
+}
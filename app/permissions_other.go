@@ -0,0 +1,8 @@
+//go:build !linux && !darwin
+
+package app
+
+// fsKeepsPermissions can't tell the filesystem apart on this platform.
+func fsKeepsPermissions(path string) (keeps bool, known bool) {
+	return false, false
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dustin/go-humanize"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+	"github.com/samber/lo"
+)
+
+// deviceHasProblem reports devices that share folders but aren't connected.
+// Unused and paused devices are expected to be disconnected.
+func deviceHasProblem(status DeviceStatus) bool {
+	return status == DeviceDisconnected || status == DeviceDisconnectedInactive
+}
+
+// problemFolders keeps the folders the status health indicator counts as problems.
+func problemFolders(folders []FolderViewModel) []FolderViewModel {
+	return lo.Filter(folders, func(f FolderViewModel, index int) bool {
+		return folderHasProblem(folderStatus(f))
+	})
+}
+
+func problemDevices(devices []DeviceViewModel, currentTime time.Time) []DeviceViewModel {
+	return lo.Filter(devices, func(d DeviceViewModel, index int) bool {
+		return deviceHasProblem(deviceStatus(d, currentTime))
+	})
+}
+
+func viewHealthyHidden(hidden int, problems int, this ThisDeviceStatus) string {
+	style := lipgloss.NewStyle().Italic(true).Faint(true).Padding(0, 1)
+	if homeDiskLow(this.MinHomeDiskFree, this.HomeDisk) {
+		warning := lipgloss.NewStyle().
+			Foreground(styles.WarningColor).
+			Padding(0, 1).
+			Render(fmt.Sprintf("⚠ %s free on the syncthing home disk",
+				humanize.IBytes(this.HomeDisk.Free)))
+		return lipgloss.JoinVertical(lipgloss.Center,
+			warning, style.Render(fmt.Sprintf("+%d healthy hidden", hidden)))
+	}
+	if problems == 0 {
+		return style.Render(fmt.Sprintf("✓ nothing needs attention, %d healthy hidden", hidden))
+	}
+
+	return style.Render(fmt.Sprintf("+%d healthy hidden", hidden))
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// inner width of the folder card
+const FOLDER_PROGRESS_WIDTH = 58
+
+// FolderProgress holds an animated bar per syncing or scanning folder ID.
+type FolderProgress map[string]progress.Model
+
+// folderProgressPercent is the synced share of the global bytes while syncing and
+// the scanned share while scanning. Other states have no bar.
+func folderProgressPercent(folder FolderViewModel) (float64, bool) {
+	switch folderStatus(folder) {
+	case Syncing, SyncPrepare:
+		if folder.Status.GlobalBytes <= 0 {
+			return 0, true
+		}
+		synced := folder.Status.GlobalBytes - folder.Status.NeedBytes
+		return float64(synced) / float64(folder.Status.GlobalBytes), true
+	case Scanning:
+		if folder.ScanProgress.Total <= 0 {
+			return 0, true
+		}
+		return float64(folder.ScanProgress.Current) / float64(folder.ScanProgress.Total), true
+	default:
+		return 0, false
+	}
+}
+
+// Sync moves the bars towards the current progress of the folders, adding and
+// dropping bars as folders start and stop syncing or scanning.
+func (p FolderProgress) Sync(folders []FolderViewModel, width int) (FolderProgress, tea.Cmd) {
+	bars := make(FolderProgress, len(p))
+	cmds := make([]tea.Cmd, 0)
+	for _, folder := range folders {
+		percent, ok := folderProgressPercent(folder)
+		if !ok {
+			continue
+		}
+
+		bar, found := p[folder.Config.ID]
+		if !found {
+			bar = progress.New(progress.WithDefaultGradient(), progress.WithoutPercentage())
+		}
+		bar.Width = width
+		if bar.Percent() != percent {
+			cmds = append(cmds, bar.SetPercent(percent))
+		}
+		bars[folder.Config.ID] = bar
+	}
+
+	return bars, tea.Batch(cmds...)
+}
+
+// Update animates the bar the frame belongs to.
+func (p FolderProgress) Update(msg progress.FrameMsg) (FolderProgress, tea.Cmd) {
+	cmds := make([]tea.Cmd, 0)
+	for id, bar := range p {
+		model, cmd := bar.Update(msg)
+		p[id] = model.(progress.Model)
+		cmds = append(cmds, cmd)
+	}
+
+	return p, tea.Batch(cmds...)
+}
+
+func (p FolderProgress) View(folderID string) string {
+	bar, found := p[folderID]
+	if !found {
+		return ""
+	}
+
+	return bar.View()
+}
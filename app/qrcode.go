@@ -0,0 +1,193 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+const DEVICE_ID_QR_BTN = "device-id-qr-btn"
+
+var deviceIDQRKeys = key.NewBinding(
+	key.WithKeys("i"),
+	key.WithHelp("i", "show this device ID as a QR code"),
+)
+
+// qrLayout is one way to draw the code, tried from the largest to the smallest.
+type qrLayout struct {
+	level qrcode.RecoveryLevel
+	// white modules around the code, scanners need at least one
+	border int
+	// two columns per module and one line per module row, otherwise half blocks
+	// fit two module rows in a line
+	large bool
+}
+
+var qrLayouts = []qrLayout{
+	{level: qrcode.Medium, border: 2, large: true},
+	{level: qrcode.Medium, border: 2},
+	{level: qrcode.Low, border: 1},
+}
+
+func (l qrLayout) rowsPerLine() int {
+	if l.large {
+		return 1
+	}
+	return 2
+}
+
+func (l qrLayout) size(modules int) (int, int) {
+	side := modules + 2*l.border
+	if l.large {
+		return side * 2, side
+	}
+	return side, (side + 1) / 2
+}
+
+// viewQRCode draws the content in the largest layout that fits the given area.
+// Dark modules are drawn black on white so the code scans on dark themes too.
+func viewQRCode(content string, width, height int) (string, error) {
+	var neededWidth, neededHeight int
+	for _, layout := range qrLayouts {
+		code, err := qrcode.New(content, layout.level)
+		if err != nil {
+			return "", err
+		}
+		code.DisableBorder = true
+		bitmap := code.Bitmap()
+
+		neededWidth, neededHeight = layout.size(len(bitmap))
+		if neededWidth <= width && neededHeight <= height {
+			return renderQRBitmap(bitmap, layout), nil
+		}
+	}
+
+	return "", fmt.Errorf("the terminal is too small for the QR code, it needs %dx%d",
+		neededWidth, neededHeight)
+}
+
+func renderQRBitmap(bitmap [][]bool, layout qrLayout) string {
+	side := len(bitmap) + 2*layout.border
+	dark := func(x, y int) bool {
+		x, y = x-layout.border, y-layout.border
+		return y >= 0 && y < len(bitmap) && x >= 0 && x < len(bitmap[y]) && bitmap[y][x]
+	}
+
+	lines := make([]string, 0, side)
+	for y := 0; y < side; y += layout.rowsPerLine() {
+		var line strings.Builder
+		for x := 0; x < side; x++ {
+			top, bottom := dark(x, y), dark(x, y+1)
+			switch {
+			case layout.large && top:
+				line.WriteString("██")
+			case layout.large:
+				line.WriteString("  ")
+			case top && bottom:
+				line.WriteString("█")
+			case top:
+				line.WriteString("▀")
+			case bottom:
+				line.WriteString("▄")
+			default:
+				line.WriteString(" ")
+			}
+		}
+		lines = append(lines, line.String())
+	}
+
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color("0")).
+		Background(lipgloss.Color("15")).
+		Render(strings.Join(lines, "\n"))
+}
+
+// DeviceIDQRModel shows this device ID as a QR code, to add it from a phone.
+type DeviceIDQRModel struct {
+	Show     bool
+	deviceID string
+	// terminal size
+	width      int
+	height     int
+	zonePrefix string
+}
+
+func NewDeviceIDQR(deviceID string, width, height int) DeviceIDQRModel {
+	return DeviceIDQRModel{
+		Show:       true,
+		deviceID:   deviceID,
+		width:      width,
+		height:     height,
+		zonePrefix: zone.NewPrefix(),
+	}
+}
+
+func (m DeviceIDQRModel) Resize(width, height int) DeviceIDQRModel {
+	m.width = width
+	m.height = height
+	return m
+}
+
+func (m DeviceIDQRModel) Update(msg tea.Msg) (DeviceIDQRModel, tea.Cmd) {
+	if !m.Show {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q", "i":
+			m.Show = false
+		}
+	case tea.MouseMsg:
+		if msg.Action != tea.MouseActionRelease || msg.Button != tea.MouseButtonLeft {
+			return m, nil
+		}
+
+		if zone.Get(m.zonePrefix+"close").InBounds(msg) ||
+			!zone.Get(m.zonePrefix+"area").InBounds(msg) {
+			m.Show = false
+		}
+	}
+
+	return m, nil
+}
+
+func (m DeviceIDQRModel) View() string {
+	// border and padding around the body, header, device ID and actions lines
+	const horizontalFrame, verticalFrame = 4, 7
+	code, err := viewQRCode(m.deviceID, m.width-horizontalFrame, m.height-verticalFrame)
+	if err != nil {
+		code = lipgloss.NewStyle().Foreground(styles.ErrorColor).Render("✗ " + err.Error())
+	}
+
+	width := min(max(lipgloss.Width(code), len(m.deviceID))+2, max(20, m.width-2))
+	header := lipgloss.NewStyle().
+		Padding(0, 1).
+		Width(width).
+		Background(styles.AccentColor).
+		Render("Device ID")
+	body := lipgloss.NewStyle().
+		Padding(0, 1).
+		Width(width).
+		Render(lipgloss.JoinVertical(lipgloss.Center,
+			code,
+			"",
+			lipgloss.NewStyle().Bold(true).Render(m.deviceID),
+		))
+	actions := lipgloss.PlaceHorizontal(width, lipgloss.Right,
+		zone.Mark(m.zonePrefix+"close", styles.BtnStyleV2.Render("Close")))
+
+	return zone.Mark(
+		m.zonePrefix+"area",
+		lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Render(
+			lipgloss.JoinVertical(lipgloss.Left, header, body, actions),
+		),
+	)
+}
@@ -0,0 +1,194 @@
+package app
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+	"github.com/samber/lo"
+)
+
+const (
+	RECONNECT_MODAL_AREA  = "reconnect-all-modal"
+	RECONNECT_CONFIRM_BTN = "confirm-reconnect-all"
+	RECONNECT_CANCEL_BTN  = "cancel-reconnect-all"
+	// time devices stay paused so that their connections are really dropped
+	RECONNECT_PAUSE = 2 * time.Second
+	// stop showing the progress when devices take longer than this to come back
+	RECONNECT_TIMEOUT = 2 * time.Minute
+)
+
+// ReconnectAll cycles pause/resume on every unpaused device so they dial again.
+type ReconnectAll struct {
+	ShowConfirm bool
+	Active      bool
+	Resumed     bool
+	Started     time.Time
+	DeviceIDs   []string
+}
+
+type PausedDevicesMsg struct {
+	deviceIDs []string
+	err       error
+}
+
+type ResumedDevicesMsg struct {
+	err error
+}
+
+func pauseDevices(httpData HttpData, deviceIDs []string) tea.Cmd {
+	return func() tea.Msg {
+		for _, deviceID := range deviceIDs {
+			if err := postDeviceAction(httpData, SYSTEM_PAUSE, deviceID); err != nil {
+				return PausedDevicesMsg{deviceIDs: deviceIDs, err: err}
+			}
+		}
+
+		return PausedDevicesMsg{deviceIDs: deviceIDs}
+	}
+}
+
+func resumeDevices(httpData HttpData, deviceIDs []string) tea.Cmd {
+	return func() tea.Msg {
+		var lastErr error
+		// keep going so a single failure doesn't leave the other devices paused
+		for _, deviceID := range deviceIDs {
+			if err := postDeviceAction(httpData, SYSTEM_RESUME, deviceID); err != nil {
+				lastErr = err
+			}
+		}
+
+		return ResumedDevicesMsg{err: lastErr}
+	}
+}
+
+func postDeviceAction(httpData HttpData, path string, deviceID string) error {
+	params := url.Values{}
+	params.Add("device", deviceID)
+	url := httpData.url.JoinPath(path)
+	url.RawQuery = params.Encode()
+	req, err := http.NewRequest(http.MethodPost, url.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	httpData.authorize(req)
+	resp, err := httpData.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s for device %s failed with status %s",
+			path, shortIdentification(deviceID), resp.Status)
+	}
+
+	return nil
+}
+
+// reconnectedDevices counts how many of the cycled devices are connected again.
+func reconnectedDevices(r ReconnectAll, devices []DeviceViewModel) int {
+	return lo.CountBy(devices, func(d DeviceViewModel) bool {
+		return lo.Contains(r.DeviceIDs, d.Config.DeviceID) && d.Connection.B.Connected
+	})
+}
+
+func (r ReconnectAll) Done(devices []DeviceViewModel, currentTime time.Time) bool {
+	if !r.Resumed {
+		return false
+	}
+
+	return reconnectedDevices(r, devices) == len(r.DeviceIDs) ||
+		currentTime.Sub(r.Started) > RECONNECT_TIMEOUT
+}
+
+func (r ReconnectAll) Label(devices []DeviceViewModel) string {
+	if !r.Resumed {
+		return "Pausing…"
+	}
+
+	return fmt.Sprintf("%d/%d devices", reconnectedDevices(r, devices), len(r.DeviceIDs))
+}
+
+func viewConfirmReconnectAll(devices int) string {
+	width := 60
+	header := lipgloss.NewStyle().
+		Padding(1, 1).
+		Width(width).
+		Background(styles.WarningColor).
+		Render("Reconnect All Devices")
+	body := lipgloss.NewStyle().Padding(1, 1).Width(width).Render(fmt.Sprintf(
+		"The %d unpaused devices will be paused and resumed to force new connections. "+
+			"Transfers in progress are interrupted.\n\nReconnect now?",
+		devices,
+	))
+	var actions string
+	{
+		layout := lipgloss.NewStyle().Padding(0, 1).Width(width)
+		btnConfirm := zone.Mark(RECONNECT_CONFIRM_BTN, styles.BtnStyleV2.Render("Reconnect"))
+		btnCancel := zone.Mark(RECONNECT_CANCEL_BTN, styles.BtnStyleV2.Render("Cancel"))
+		actions = viewFooter(
+			layout.GetWidth()-layout.GetHorizontalPadding(),
+			[]string{btnConfirm},
+			[]string{btnCancel},
+		)
+		actions = layout.Render(actions)
+	}
+
+	return zone.Mark(
+		RECONNECT_MODAL_AREA,
+		lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Render(
+			lipgloss.JoinVertical(lipgloss.Left, header, body, actions),
+		),
+	)
+}
+
+func (m model) startReconnectAll() (model, tea.Cmd) {
+	m.reconnect.ShowConfirm = false
+	deviceIDs := lo.FilterMap(m.devices, func(d DeviceViewModel, index int) (string, bool) {
+		return d.Config.DeviceID, !d.Config.Paused
+	})
+	if len(deviceIDs) == 0 {
+		return m, nil
+	}
+
+	m.reconnect = ReconnectAll{Active: true, Started: m.currentTime, DeviceIDs: deviceIDs}
+	return m, pauseDevices(m.httpData, deviceIDs)
+}
+
+func handleMouseEventsReconnectModal(m model, msg tea.MouseMsg) (model, tea.Cmd) {
+	if msg.Action != tea.MouseActionRelease || msg.Button != tea.MouseButtonLeft {
+		return m, nil
+	}
+
+	if zone.Get(RECONNECT_CONFIRM_BTN).InBounds(msg) {
+		return m.startReconnectAll()
+	}
+
+	// cancel button or click out of modal bounds
+	if zone.Get(RECONNECT_CANCEL_BTN).InBounds(msg) ||
+		!zone.Get(RECONNECT_MODAL_AREA).InBounds(msg) {
+		m.reconnect.ShowConfirm = false
+	}
+
+	return m, nil
+}
+
+func handleKeyBoardEventsReconnectModal(m model, msg tea.KeyMsg) (model, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "y":
+		return m.startReconnectAll()
+	case "esc", "n":
+		m.reconnect.ShowConfirm = false
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	}
+
+	return m, nil
+}
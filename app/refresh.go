@@ -0,0 +1,61 @@
+package app
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pdrolopes/syncthing_TUI/syncthing"
+)
+
+var refreshKeys = key.NewBinding(
+	key.WithKeys("r"),
+	key.WithHelp("r", "refresh now"),
+)
+
+// RefreshEndedMsg arrives once every request of a manual refresh answered.
+type RefreshEndedMsg struct{}
+
+// refreshSystemStatus fetches the status once, the periodic fetch keeps its own
+// schedule.
+func refreshSystemStatus(httpData HttpData) tea.Cmd {
+	return func() tea.Msg {
+		msg := fetchSystemStatus(httpData)().(FetchedSystemStatusMsg)
+		msg.manual = true
+		return msg
+	}
+}
+
+// refreshSystemConnections measures the rates since the last connections fetch,
+// whichever one it was.
+func refreshSystemConnections(httpData HttpData, prev syncthing.SystemConnection) tea.Cmd {
+	return func() tea.Msg {
+		msg := fetchSystemConnections(httpData, prev)().(FetchedSystemConnectionsMsg)
+		msg.manual = true
+		return msg
+	}
+}
+
+// refresh asks again what the dashboard polls, without waiting for the timers.
+// The folder statuses and completions follow the config.
+func (m model) refresh() tea.Cmd {
+	return tea.Sequence(
+		tea.Batch(
+			refreshSystemStatus(m.httpData),
+			refreshSystemConnections(m.httpData, m.connections),
+			fetchConfig(m.httpData),
+			fetchDeviceStats(m.httpData),
+			fetchFolderStats(m.httpData),
+			fetchPendingDevices(m.httpData),
+			fetchPendingFolders(m.httpData),
+		),
+		func() tea.Msg { return RefreshEndedMsg{} },
+	)
+}
+
+func viewRefreshing(refreshing bool) string {
+	if !refreshing {
+		return ""
+	}
+
+	return lipgloss.NewStyle().Italic(true).Faint(true).Padding(0, 1).Render("↻ refreshing…")
+}
@@ -0,0 +1,115 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+	"github.com/samber/lo"
+)
+
+const (
+	REMOVE_DEVICE_MODAL_AREA  = "remove-device-modal"
+	REMOVE_DEVICE_CONFIRM_BTN = "confirm-remove-device"
+	REMOVE_DEVICE_CANCEL_BTN  = "cancel-remove-device"
+)
+
+type ConfirmRemoveDevice struct {
+	Show     bool
+	deviceID string
+	name     string
+	// names of the devices this one introduced
+	introduced []string
+}
+
+func newConfirmRemoveDevice(
+	device DeviceViewModel,
+	devices []DeviceViewModel,
+) ConfirmRemoveDevice {
+	return ConfirmRemoveDevice{
+		Show:     true,
+		deviceID: device.Config.DeviceID,
+		name:     device.Config.Name,
+		introduced: lo.FilterMap(devices, func(d DeviceViewModel, index int) (string, bool) {
+			return d.Config.Name, d.Config.IntroducedBy == device.Config.DeviceID
+		}),
+	}
+}
+
+func viewConfirmRemoveDevice(confirm ConfirmRemoveDevice) string {
+	width := 60
+	header := lipgloss.NewStyle().
+		Padding(1, 1).
+		Width(width).
+		Background(styles.ErrorColor).
+		Render("Remove Device")
+	rows := []string{fmt.Sprintf(
+		"%s will be removed from the configuration and stop sharing folders with this device.",
+		confirm.name,
+	)}
+	if len(confirm.introduced) > 0 {
+		rows = append(rows, "", lipgloss.NewStyle().Foreground(styles.WarningColor).Render(
+			fmt.Sprintf("⚠ It is the introducer of %s. They stay configured, "+
+				"but their changes won't be synced from it anymore.",
+				strings.Join(confirm.introduced, ", ")),
+		))
+	}
+	rows = append(rows, "", "Are you sure you want to remove this device?")
+	body := lipgloss.NewStyle().
+		Padding(1, 1).
+		Width(width).
+		Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+	var actions string
+	{
+		layout := lipgloss.NewStyle().Padding(0, 1).Width(width)
+		btnConfirm := zone.Mark(REMOVE_DEVICE_CONFIRM_BTN, styles.NegativeBtn.Render("Remove"))
+		btnCancel := zone.Mark(REMOVE_DEVICE_CANCEL_BTN, styles.BtnStyleV2.Render("Cancel"))
+		actions = viewFooter(
+			layout.GetWidth()-layout.GetHorizontalPadding(),
+			[]string{btnConfirm},
+			[]string{btnCancel},
+		)
+		actions = layout.Render(actions)
+	}
+
+	return zone.Mark(
+		REMOVE_DEVICE_MODAL_AREA,
+		lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Render(
+			lipgloss.JoinVertical(lipgloss.Left, header, body, actions),
+		),
+	)
+}
+
+func handleMouseEventsRemoveDeviceModal(m model, msg tea.MouseMsg) (model, tea.Cmd) {
+	if msg.Action != tea.MouseActionRelease || msg.Button != tea.MouseButtonLeft {
+		return m, nil
+	}
+
+	if zone.Get(REMOVE_DEVICE_CONFIRM_BTN).InBounds(msg) {
+		deviceID := m.confirmRemoveDevice.deviceID
+		m.confirmRemoveDevice = ConfirmRemoveDevice{}
+		return m, deleteDevice(m.httpData, deviceID)
+	}
+
+	// cancel button or click out of modal bounds
+	if zone.Get(REMOVE_DEVICE_CANCEL_BTN).InBounds(msg) ||
+		!zone.Get(REMOVE_DEVICE_MODAL_AREA).InBounds(msg) {
+		m.confirmRemoveDevice = ConfirmRemoveDevice{}
+	}
+
+	return m, nil
+}
+
+func handleKeyBoardEventsRemoveDeviceModal(m model, msg tea.KeyMsg) (model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "n":
+		m.confirmRemoveDevice = ConfirmRemoveDevice{}
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	}
+
+	return m, nil
+}
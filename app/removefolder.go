@@ -0,0 +1,94 @@
+package app
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+)
+
+const (
+	REMOVE_FOLDER_MODAL_AREA  = "remove-folder-modal"
+	REMOVE_FOLDER_CONFIRM_BTN = "confirm-remove-folder"
+	REMOVE_FOLDER_CANCEL_BTN  = "cancel-remove-folder"
+)
+
+type ConfirmRemoveFolder struct {
+	Show     bool
+	folderID string
+	label    string
+	path     string
+}
+
+func viewConfirmRemoveFolder(confirm ConfirmRemoveFolder) string {
+	width := 60
+	header := lipgloss.NewStyle().
+		Padding(1, 1).
+		Width(width).
+		Background(styles.ErrorColor).
+		Render("Remove Folder")
+	details := spaceAroundTable().Width(width-2).
+		Row("Folder", confirm.label).
+		Row("Path", confirm.path)
+	body := lipgloss.NewStyle().Padding(1, 1).Width(width).Render(lipgloss.JoinVertical(
+		lipgloss.Left,
+		details.Render(),
+		"",
+		fmt.Sprintf("%s will stop syncing with every device. "+
+			"The files on disk are kept.", confirm.label),
+		"",
+		"Are you sure you want to remove this folder?",
+	))
+	var actions string
+	{
+		layout := lipgloss.NewStyle().Padding(0, 1).Width(width)
+		btnConfirm := zone.Mark(REMOVE_FOLDER_CONFIRM_BTN, styles.NegativeBtn.Render("Remove"))
+		btnCancel := zone.Mark(REMOVE_FOLDER_CANCEL_BTN, styles.BtnStyleV2.Render("Cancel"))
+		actions = viewFooter(
+			layout.GetWidth()-layout.GetHorizontalPadding(),
+			[]string{btnConfirm},
+			[]string{btnCancel},
+		)
+		actions = layout.Render(actions)
+	}
+
+	return zone.Mark(
+		REMOVE_FOLDER_MODAL_AREA,
+		lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Render(
+			lipgloss.JoinVertical(lipgloss.Left, header, body, actions),
+		),
+	)
+}
+
+func handleMouseEventsRemoveFolderModal(m model, msg tea.MouseMsg) (model, tea.Cmd) {
+	if msg.Action != tea.MouseActionRelease || msg.Button != tea.MouseButtonLeft {
+		return m, nil
+	}
+
+	if zone.Get(REMOVE_FOLDER_CONFIRM_BTN).InBounds(msg) {
+		folderID := m.confirmRemoveFolder.folderID
+		m.confirmRemoveFolder = ConfirmRemoveFolder{}
+		return m, deleteFolder(m.httpData, folderID)
+	}
+
+	// cancel button or click out of modal bounds
+	if zone.Get(REMOVE_FOLDER_CANCEL_BTN).InBounds(msg) ||
+		!zone.Get(REMOVE_FOLDER_MODAL_AREA).InBounds(msg) {
+		m.confirmRemoveFolder = ConfirmRemoveFolder{}
+	}
+
+	return m, nil
+}
+
+func handleKeyBoardEventsRemoveFolderModal(m model, msg tea.KeyMsg) (model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "n":
+		m.confirmRemoveFolder = ConfirmRemoveFolder{}
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	}
+
+	return m, nil
+}
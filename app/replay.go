@@ -0,0 +1,387 @@
+package app
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pdrolopes/syncthing_TUI/syncthing"
+	"github.com/samber/lo"
+)
+
+// recordedMsg is a line of the DEBUG messages log. Messages that can't be replayed
+// are still written with their type to follow the flow.
+type recordedMsg struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// recordedFetch holds the result of a fetch, errors are kept as their message.
+type recordedFetch[T any] struct {
+	Value T      `json:"value"`
+	Err   string `json:"err,omitempty"`
+}
+
+type recordedFolderStatus struct {
+	ID           string                 `json:"id"`
+	FolderStatus syncthing.FolderStatus `json:"folderStatus"`
+	Err          string                 `json:"err,omitempty"`
+}
+
+type recordedEvents struct {
+	Events []syncthing.Event[any] `json:"events"`
+	Since  int                    `json:"since"`
+	Err    string                 `json:"err,omitempty"`
+}
+
+type recordedConnections struct {
+	PrevConnections syncthing.SystemConnection `json:"prevConnections"`
+	Connections     syncthing.SystemConnection `json:"connections"`
+	Err             string                     `json:"err,omitempty"`
+}
+
+type recordedCompletion struct {
+	DeviceID      string                     `json:"deviceID"`
+	FolderID      string                     `json:"folderID"`
+	Completion    syncthing.StatusCompletion `json:"completion"`
+	HasCompletion bool                       `json:"hasCompletion"`
+	Err           string                     `json:"err,omitempty"`
+}
+
+type recordedPostPut struct {
+	Action   string `json:"action"`
+	FolderID string `json:"folderID"`
+	Err      string `json:"err,omitempty"`
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Error()
+}
+
+func stringErr(s string) error {
+	switch s {
+	case "":
+		return nil
+	case ErrEndpointUnavailable.Error():
+		return ErrEndpointUnavailable
+	}
+
+	return errors.New(s)
+}
+
+func fetched[T any](value T, err error) recordedFetch[T] {
+	return recordedFetch[T]{Value: value, Err: errString(err)}
+}
+
+// redactMsg hides the characters typed in the password inputs, the log is meant to
+// be attached to bug reports. The keys are kept so a replay still moves the focus.
+func (m model) redactMsg(msg tea.Msg) tea.Msg {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok || !m.encryptionModal.Show || len(key.Runes) == 0 {
+		return msg
+	}
+
+	key.Runes = []rune(strings.Repeat("*", len(key.Runes)))
+	return key
+}
+
+// recordMsg writes msg as a JSON line of the messages log.
+func recordMsg(w io.Writer, msg tea.Msg) error {
+	var data any
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		data = tea.Key(msg)
+	case tea.MouseMsg:
+		data = tea.MouseEvent(msg)
+	case tea.WindowSizeMsg:
+		data = msg
+	case TickedCurrentTimeMsg:
+		data = msg.currentTime
+	case FetchedFolderStatus:
+		data = recordedFolderStatus{
+			ID:           msg.id,
+			FolderStatus: msg.folderStatus,
+			Err:          errString(msg.err),
+		}
+	case FetchedEventsMsg:
+		events := lo.Map(msg.events, func(e syncthing.Event[any], index int) syncthing.Event[any] {
+			if config, ok := e.Data.(syncthing.Config); ok {
+				e.Data = redactConfig(config)
+			}
+			return e
+		})
+		data = recordedEvents{Events: events, Since: msg.since, Err: errString(msg.err)}
+	case FetchedSystemStatusMsg:
+		data = fetched(msg.status, msg.err)
+	case FetchedSystemVersionMsg:
+		data = fetched(msg.version, msg.err)
+	case FetchedSystemConnectionsMsg:
+		data = recordedConnections{
+			PrevConnections: msg.prevConnections,
+			Connections:     msg.connections,
+			Err:             errString(msg.err),
+		}
+	case FetchedConfig:
+		// the log is meant to be shared in bug reports
+		data = fetched(redactConfig(msg.config), msg.err)
+	case FetchedFolderStats:
+		data = fetched(msg.folderStats, msg.err)
+	case FetchedDeviceStats:
+		data = fetched(msg.deviceStats, msg.err)
+	case FetchedCompletion:
+		data = recordedCompletion{
+			DeviceID:      msg.deviceID,
+			FolderID:      msg.folderID,
+			Completion:    msg.completion,
+			HasCompletion: msg.hasCompletion,
+			Err:           errString(msg.err),
+		}
+	case UserPostPutEndedMsg:
+		data = recordedPostPut{
+			Action:   msg.action,
+			FolderID: msg.folderID,
+			Err:      errString(msg.err),
+		}
+	case FetchedPendingDevices:
+		data = fetched(msg.devices, msg.err)
+	case FetchedPendingFolders:
+		data = fetched(msg.folders, msg.err)
+	case FetchedHomeDiskMsg:
+		data = fetched(msg.disk, msg.err)
+	default:
+		// only the exported fields, these are not replayed
+		data = msg
+	}
+
+	record := recordedMsg{Type: fmt.Sprintf("%T", msg)}
+	// messages holding commands or channels can't be marshaled, the type is enough
+	if raw, err := json.Marshal(data); err == nil && data != nil {
+		record.Data = raw
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(line, '\n'))
+	return err
+}
+
+func decodeFetched[T any](raw json.RawMessage) (T, error, error) {
+	var record recordedFetch[T]
+	if err := json.Unmarshal(raw, &record); err != nil {
+		return record.Value, nil, err
+	}
+
+	return record.Value, stringErr(record.Err), nil
+}
+
+// decodeRecordedMsg rebuilds a message of the log. It returns false for messages
+// that aren't replayed.
+func decodeRecordedMsg(record recordedMsg) (tea.Msg, bool, error) {
+	if len(record.Data) == 0 {
+		return nil, false, nil
+	}
+
+	switch record.Type {
+	case fmt.Sprintf("%T", tea.KeyMsg{}):
+		var key tea.Key
+		err := json.Unmarshal(record.Data, &key)
+		return tea.KeyMsg(key), true, err
+	case fmt.Sprintf("%T", tea.MouseMsg{}):
+		var mouse tea.MouseEvent
+		err := json.Unmarshal(record.Data, &mouse)
+		return tea.MouseMsg(mouse), true, err
+	case fmt.Sprintf("%T", tea.WindowSizeMsg{}):
+		var size tea.WindowSizeMsg
+		err := json.Unmarshal(record.Data, &size)
+		return size, true, err
+	case fmt.Sprintf("%T", TickedCurrentTimeMsg{}):
+		var currentTime time.Time
+		err := json.Unmarshal(record.Data, &currentTime)
+		return TickedCurrentTimeMsg{currentTime: currentTime}, true, err
+	case fmt.Sprintf("%T", FetchedFolderStatus{}):
+		var r recordedFolderStatus
+		err := json.Unmarshal(record.Data, &r)
+		return FetchedFolderStatus{
+			folderStatus: r.FolderStatus,
+			id:           r.ID,
+			err:          stringErr(r.Err),
+		}, true, err
+	case fmt.Sprintf("%T", FetchedEventsMsg{}):
+		var r struct {
+			Events []syncthing.Event[json.RawMessage] `json:"events"`
+			Since  int                                `json:"since"`
+			Err    string                             `json:"err,omitempty"`
+		}
+		if err := json.Unmarshal(record.Data, &r); err != nil {
+			return nil, false, err
+		}
+		msg := FetchedEventsMsg{since: r.Since, err: stringErr(r.Err)}
+		for _, e := range r.Events {
+			event, err := parseEvent(e)
+			if err != nil {
+				msg.decodeErrors = append(msg.decodeErrors, err)
+			}
+			msg.events = append(msg.events, event)
+		}
+		return msg, true, nil
+	case fmt.Sprintf("%T", FetchedSystemStatusMsg{}):
+		status, msgErr, err := decodeFetched[syncthing.SystemStatus](record.Data)
+		return FetchedSystemStatusMsg{status: status, err: msgErr}, true, err
+	case fmt.Sprintf("%T", FetchedSystemVersionMsg{}):
+		version, msgErr, err := decodeFetched[syncthing.SystemVersion](record.Data)
+		return FetchedSystemVersionMsg{version: version, err: msgErr}, true, err
+	case fmt.Sprintf("%T", FetchedSystemConnectionsMsg{}):
+		var r recordedConnections
+		err := json.Unmarshal(record.Data, &r)
+		return FetchedSystemConnectionsMsg{
+			prevConnections: r.PrevConnections,
+			connections:     r.Connections,
+			err:             stringErr(r.Err),
+		}, true, err
+	case fmt.Sprintf("%T", FetchedConfig{}):
+		config, msgErr, err := decodeFetched[syncthing.Config](record.Data)
+		return FetchedConfig{config: config, err: msgErr}, true, err
+	case fmt.Sprintf("%T", FetchedFolderStats{}):
+		stats, msgErr, err := decodeFetched[map[string]syncthing.FolderStats](record.Data)
+		return FetchedFolderStats{folderStats: stats, err: msgErr}, true, err
+	case fmt.Sprintf("%T", FetchedDeviceStats{}):
+		stats, msgErr, err := decodeFetched[map[string]syncthing.DeviceStats](record.Data)
+		return FetchedDeviceStats{deviceStats: stats, err: msgErr}, true, err
+	case fmt.Sprintf("%T", FetchedCompletion{}):
+		var r recordedCompletion
+		err := json.Unmarshal(record.Data, &r)
+		return FetchedCompletion{
+			deviceID:      r.DeviceID,
+			folderID:      r.FolderID,
+			completion:    r.Completion,
+			hasCompletion: r.HasCompletion,
+			err:           stringErr(r.Err),
+		}, true, err
+	case fmt.Sprintf("%T", UserPostPutEndedMsg{}):
+		var r recordedPostPut
+		err := json.Unmarshal(record.Data, &r)
+		return UserPostPutEndedMsg{
+			action:   r.Action,
+			folderID: r.FolderID,
+			err:      stringErr(r.Err),
+		}, true, err
+	case fmt.Sprintf("%T", FetchedPendingDevices{}):
+		devices, msgErr, err := decodeFetched[map[string]syncthing.PendingDeviceInfo](record.Data)
+		return FetchedPendingDevices{devices: devices, err: msgErr}, true, err
+	case fmt.Sprintf("%T", FetchedPendingFolders{}):
+		folders, msgErr, err := decodeFetched[map[string]syncthing.PendingFolderInfo](record.Data)
+		return FetchedPendingFolders{folders: folders, err: msgErr}, true, err
+	case fmt.Sprintf("%T", FetchedHomeDiskMsg{}):
+		disk, msgErr, err := decodeFetched[HomeDisk](record.Data)
+		return FetchedHomeDiskMsg{disk: disk, err: msgErr}, true, err
+	}
+
+	return nil, false, nil
+}
+
+// readRecordedMsgs reads the replayable messages of a messages log.
+func readRecordedMsgs(r io.Reader) ([]tea.Msg, error) {
+	var msgs []tea.Msg
+	scanner := bufio.NewScanner(r)
+	// a config can easily go over the default line limit
+	scanner.Buffer(nil, 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var record recordedMsg
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		msg, ok, err := decodeRecordedMsg(record)
+		if err != nil {
+			return nil, fmt.Errorf("line %d %s: %w", line, record.Type, err)
+		}
+		if ok {
+			msgs = append(msgs, msg)
+		}
+	}
+
+	return msgs, scanner.Err()
+}
+
+// ReplayModel feeds the messages of a DEBUG log through the model one at a time.
+// Commands returned by the model are dropped, so nothing is fetched from syncthing
+// and the same log always ends in the same state.
+type ReplayModel struct {
+	model model
+	msgs  []tea.Msg
+	next  int
+}
+
+func NewReplay(options Options, r io.Reader) (ReplayModel, error) {
+	msgs, err := readRecordedMsgs(r)
+	if err != nil {
+		return ReplayModel{}, err
+	}
+
+	options.Replay = true
+	m := NewModel(options)
+	// syncthing is never reached during a replay
+	m.err = nil
+	if m.httpData.apiKey == "" {
+		m.httpData.apiKey = "replay"
+	}
+
+	return ReplayModel{model: m, msgs: msgs}, nil
+}
+
+func (r ReplayModel) Init() tea.Cmd {
+	return tea.SetWindowTitle("tui-syncthing replay")
+}
+
+func (r ReplayModel) step() ReplayModel {
+	if r.next >= len(r.msgs) {
+		return r
+	}
+
+	updated, _ := r.model.Update(r.msgs[r.next])
+	r.model = updated.(model)
+	r.next++
+	return r
+}
+
+func (r ReplayModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "ctrl+c":
+			return r, tea.Quit
+		case "ctrl+n":
+			return r.step(), nil
+		case "ctrl+e":
+			for r.next < len(r.msgs) {
+				r = r.step()
+			}
+			return r, nil
+		}
+	}
+
+	updated, _ := r.model.Update(msg)
+	r.model = updated.(model)
+	return r, nil
+}
+
+func (r ReplayModel) View() string {
+	status := lipgloss.NewStyle().Reverse(true).Padding(0, 1).Render(fmt.Sprintf(
+		"replay %d/%d   [ctrl+n] next   [ctrl+e] end   [ctrl+c] quit",
+		r.next,
+		len(r.msgs),
+	))
+
+	return lipgloss.JoinVertical(lipgloss.Left, status, r.model.View())
+}
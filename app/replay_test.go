@@ -0,0 +1,155 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRedactMsg(t *testing.T) {
+	password := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("hunter2")}
+	tests := []struct {
+		name  string
+		modal bool
+		msg   tea.Msg
+		want  tea.Msg
+	}{
+		{name: "typed without a password input", msg: password, want: password},
+		{
+			name:  "typed in a password input",
+			modal: true,
+			msg:   password,
+			want:  tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("*******")},
+		},
+		{
+			name:  "pasted in a password input",
+			modal: true,
+			msg:   tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("secret"), Paste: true},
+			want:  tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("******"), Paste: true},
+		},
+		{
+			name:  "focus moves are kept",
+			modal: true,
+			msg:   tea.KeyMsg{Type: tea.KeyTab},
+			want:  tea.KeyMsg{Type: tea.KeyTab},
+		},
+		{
+			name:  "other messages are kept",
+			modal: true,
+			msg:   tea.WindowSizeMsg{Width: 80, Height: 24},
+			want:  tea.WindowSizeMsg{Width: 80, Height: 24},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := model{encryptionModal: EncryptionPasswordsModel{Show: tt.modal}}
+			got := m.redactMsg(tt.msg)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("redactMsg() = %#v, want %#v", got, tt.want)
+			}
+
+			var log bytes.Buffer
+			if err := recordMsg(&log, got); err != nil {
+				t.Fatal(err)
+			}
+			if tt.modal && strings.Contains(log.String(), "hunter2") {
+				t.Errorf("recorded %s, want the password left out", log.String())
+			}
+		})
+	}
+}
+
+func TestNewReplayKeepsTheLog(t *testing.T) {
+	// NewModel opens messages.log in the working directory
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+	t.Setenv("DEBUG", "1")
+
+	var log bytes.Buffer
+	if err := recordMsg(&log, tea.WindowSizeMsg{Width: 80, Height: 24}); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(".", "messages.log")
+	if err := os.WriteFile(path, log.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	replay, err := NewReplay(Options{}, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(replay.msgs) != 1 || replay.model.dump != nil {
+		t.Errorf("NewReplay() = %d messages, recording %v", len(replay.msgs), replay.model.dump)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var record recordedMsg
+	if err := json.Unmarshal(data, &record); err != nil || !bytes.Equal(data, log.Bytes()) {
+		t.Errorf("messages.log = %q after NewReplay, want it untouched", data)
+	}
+}
+
+func TestNewModelRecordsPrivately(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+	t.Setenv("DEBUG", "1")
+
+	tests := []struct {
+		name     string
+		existing bool
+	}{
+		{name: "new log"},
+		{name: "log left readable by an older run", existing: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(".", "messages.log")
+			_ = os.Remove(path)
+			if tt.existing {
+				if err := os.WriteFile(path, nil, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			m := NewModel(Options{})
+			if file, ok := m.dump.(*os.File); ok {
+				defer file.Close()
+			}
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Mode().Perm() != 0o600 {
+				t.Errorf("messages.log mode = %v, want 0600", info.Mode().Perm())
+			}
+		})
+	}
+}
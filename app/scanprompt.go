@@ -0,0 +1,64 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ScanPrompt asks for the directory to rescan in a folder, to spare a full scan
+// of a large folder when only one directory changed.
+type ScanPrompt struct {
+	// folder to scan, empty when not asking
+	FolderID string
+	input    textinput.Model
+}
+
+func NewScanPrompt(folder FolderViewModel) ScanPrompt {
+	input := textinput.New()
+	input.Placeholder = "whole folder"
+	input.CharLimit = 255
+	input.Width = 30
+	input.Focus()
+
+	return ScanPrompt{FolderID: folder.Config.ID, input: input}
+}
+
+func (p ScanPrompt) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (p ScanPrompt) Active() bool {
+	return p.FolderID != ""
+}
+
+func (p ScanPrompt) Update(msg tea.Msg, httpData HttpData) (ScanPrompt, tea.Cmd) {
+	if !p.Active() {
+		return p, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.Type {
+		case tea.KeyEsc:
+			return ScanPrompt{}, nil
+		case tea.KeyEnter:
+			// syncthing takes the path relative to the folder root
+			sub := strings.Trim(strings.TrimSpace(p.input.Value()), "/")
+			return ScanPrompt{}, postScan(httpData, p.FolderID, sub)
+		}
+	}
+
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	return p, cmd
+}
+
+func (p ScanPrompt) View() string {
+	return lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.JoinHorizontal(lipgloss.Top, "Rescan path ", p.input.View()),
+		lipgloss.NewStyle().Italic(true).Faint(true).
+			Render("relative to the folder root, enter to scan, esc to cancel"),
+	)
+}
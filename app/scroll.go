@@ -0,0 +1,116 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+)
+
+const SCROLL_WHEEL_LINES = 3
+
+var scrollPageDownKeys = key.NewBinding(
+	key.WithKeys("pgdown"),
+	key.WithHelp("pgdown", "scroll down a page"),
+)
+
+var scrollPageUpKeys = key.NewBinding(
+	key.WithKeys("pgup"),
+	key.WithHelp("pgup", "scroll up a page"),
+)
+
+// plain arrows move the selection, which scrolls along
+var scrollDownKeys = key.NewBinding(
+	key.WithKeys("shift+down"),
+	key.WithHelp("shift+↓", "scroll down a line"),
+)
+
+var scrollUpKeys = key.NewBinding(
+	key.WithKeys("shift+up"),
+	key.WithHelp("shift+↑", "scroll up a line"),
+)
+
+var scrollTopKeys = key.NewBinding(
+	key.WithKeys("home"),
+	key.WithHelp("home", "scroll to the top"),
+)
+
+var scrollBottomKeys = key.NewBinding(
+	key.WithKeys("end"),
+	key.WithHelp("end", "scroll to the bottom"),
+)
+
+// dashboardViewport fits the columns in the height left under the fixed header.
+// Only the offset is kept in the model, the content is set on every render.
+func (m model) dashboardViewport(header, columns string) viewport.Model {
+	vp := m.viewport
+	vp.Width = m.width
+	vp.Height = m.height
+	if header != "" {
+		vp.Height -= lipgloss.Height(header)
+	}
+	vp.Height = max(1, vp.Height)
+	vp.SetContent(columns)
+	return vp
+}
+
+func (m model) scroll(move func(vp *viewport.Model)) model {
+	vp := m.dashboardViewport(m.viewDashboardHeader(), m.viewDashboardColumns())
+	move(&vp)
+	m.viewport = vp
+	return m
+}
+
+// revealSelection scrolls just enough to show the header of the selected card.
+func (m model) revealSelection() model {
+	if m.selection.ID == "" {
+		return m
+	}
+
+	m, _ = m.revealCard(m.selection.ID)
+	return m
+}
+
+// revealCard scrolls just enough to show the header of the folder or device card,
+// found tells if the card is listed.
+func (m model) revealCard(id string) (model, bool) {
+	if m.viewMode != ViewDefault {
+		return m, false
+	}
+
+	columns := m.viewDashboardColumns()
+	vp := m.dashboardViewport(m.viewDashboardHeader(), columns)
+	// folder and device cards share the header mark format
+	line, found := markLine(columns, id+"-header")
+	if !found {
+		return m, false
+	}
+	// one line above for the top border of the card
+	if top := max(0, line-1); top < vp.YOffset {
+		vp.SetYOffset(top)
+	} else if line >= vp.YOffset+vp.Height {
+		vp.SetYOffset(line - vp.Height + 1)
+	}
+	m.viewport = vp
+
+	return m, true
+}
+
+// markLine returns the line where the zone id starts in the rendered view, before
+// zone.Scan strips the markers.
+func markLine(view string, id string) (int, bool) {
+	marked := zone.Mark(id, " ")
+	if marked == " " {
+		return 0, false
+	}
+	marker := marked[:(len(marked)-1)/2]
+
+	index := strings.Index(view, marker)
+	if index < 0 {
+		return 0, false
+	}
+
+	return strings.Count(view[:index], "\n"), true
+}
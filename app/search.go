@@ -0,0 +1,120 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samber/lo"
+)
+
+var searchKeys = key.NewBinding(
+	key.WithKeys("/"),
+	key.WithHelp("/", "search folders and devices"),
+)
+
+// Search narrows the folder and device lists to the ones matching the query as
+// it is typed. Enter keeps the query applied, Esc clears it.
+type Search struct {
+	Editing bool
+	input   textinput.Model
+}
+
+func NewSearch() Search {
+	input := textinput.New()
+	input.Prompt = "/ "
+	input.Placeholder = "label, name or ID"
+	input.CharLimit = 100
+	input.Width = 30
+
+	return Search{input: input}
+}
+
+func (s Search) Query() string {
+	return strings.TrimSpace(s.input.Value())
+}
+
+// Active is true while a query narrows the lists.
+func (s Search) Active() bool {
+	return s.Editing || s.Query() != ""
+}
+
+func (s Search) Focus() (Search, tea.Cmd) {
+	s.Editing = true
+	return s, s.input.Focus()
+}
+
+func (s Search) Clear() Search {
+	s.Editing = false
+	s.input.Reset()
+	s.input.Blur()
+	return s
+}
+
+func (s Search) Update(msg tea.Msg) (Search, tea.Cmd) {
+	if !s.Editing {
+		return s, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.Type {
+		case tea.KeyEsc:
+			return s.Clear(), nil
+		case tea.KeyEnter:
+			s.Editing = false
+			s.input.Blur()
+			return s, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	s.input, cmd = s.input.Update(msg)
+	return s, cmd
+}
+
+func containsFold(values []string, query string) bool {
+	query = strings.ToLower(query)
+	return lo.SomeBy(values, func(value string) bool {
+		return strings.Contains(strings.ToLower(value), query)
+	})
+}
+
+func searchFolders(folders []FolderViewModel, query string) []FolderViewModel {
+	if query == "" {
+		return folders
+	}
+
+	return lo.Filter(folders, func(f FolderViewModel, index int) bool {
+		return containsFold([]string{f.Config.Label, f.Config.ID}, query)
+	})
+}
+
+func searchDevices(devices []DeviceViewModel, query string) []DeviceViewModel {
+	if query == "" {
+		return devices
+	}
+
+	return lo.Filter(devices, func(d DeviceViewModel, index int) bool {
+		return containsFold([]string{d.Config.Name, d.Config.DeviceID}, query)
+	})
+}
+
+func viewSearch(search Search, hidden int) string {
+	if !search.Active() {
+		return ""
+	}
+
+	hint := "enter to keep, esc to clear"
+	if !search.Editing {
+		hint = "/ to change, esc to clear"
+	}
+	return lipgloss.NewStyle().Padding(0, 1).Render(lipgloss.JoinHorizontal(lipgloss.Top,
+		search.input.View(),
+		" ",
+		lipgloss.NewStyle().Italic(true).Faint(true).
+			Render(fmt.Sprintf("%d hidden (%s)", hidden, hint)),
+	))
+}
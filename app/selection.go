@@ -0,0 +1,209 @@
+package app
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pdrolopes/syncthing_TUI/syncthing"
+	"github.com/samber/lo"
+)
+
+// how long to wait for the next letter of a jump prefix
+const JUMP_TIMEOUT = time.Second
+
+type Section int
+
+const (
+	SectionFolders Section = iota
+	SectionDevices
+)
+
+func (s Section) Other() Section {
+	return lo.Ternary(s == SectionFolders, SectionDevices, SectionFolders)
+}
+
+// Selection points to the folder or device card the keyboard actions apply to.
+type Selection struct {
+	Section Section
+	// folder or device ID, empty when nothing is selected
+	ID string
+}
+
+func (s Selection) FolderID() string {
+	return lo.Ternary(s.Section == SectionFolders, s.ID, "")
+}
+
+func (s Selection) DeviceID() string {
+	return lo.Ternary(s.Section == SectionDevices, s.ID, "")
+}
+
+// JumpPrefix holds the letters typed after the jump key.
+type JumpPrefix struct {
+	Active  bool
+	Prefix  string
+	LastKey time.Time
+}
+
+func (j JumpPrefix) Type(letter string, now time.Time) JumpPrefix {
+	if now.Sub(j.LastKey) > JUMP_TIMEOUT {
+		j.Prefix = ""
+	}
+	j.Prefix += strings.ToLower(letter)
+	j.LastKey = now
+
+	return j
+}
+
+func folderName(folder FolderViewModel) string {
+	return folderConfigName(folder.Config)
+}
+
+// folderConfigName falls back to the folder ID when there is no label, like syncthing does.
+func folderConfigName(config syncthing.FolderConfig) string {
+	if config.Label != "" {
+		return config.Label
+	}
+
+	return config.ID
+}
+
+// jumpTo returns the selection of the first item in the section whose name starts with prefix.
+func jumpTo(
+	section Section,
+	prefix string,
+	folders []FolderViewModel,
+	devices []DeviceViewModel,
+) (Selection, bool) {
+	switch section {
+	case SectionFolders:
+		for _, f := range folders {
+			if strings.HasPrefix(strings.ToLower(folderName(f)), prefix) {
+				return Selection{Section: SectionFolders, ID: f.Config.ID}, true
+			}
+		}
+	case SectionDevices:
+		for _, d := range devices {
+			if strings.HasPrefix(strings.ToLower(d.Config.Name), prefix) {
+				return Selection{Section: SectionDevices, ID: d.Config.DeviceID}, true
+			}
+		}
+	}
+
+	return Selection{}, false
+}
+
+// pinnedFirst moves the pinned items to the top, keeping the order within both groups.
+func pinnedFirst[T any](items []T, pinned []string, id func(T) string) []T {
+	sorted := make([]T, 0, len(items))
+	sorted = append(sorted, lo.Filter(items, func(item T, index int) bool {
+		return lo.Contains(pinned, id(item))
+	})...)
+	sorted = append(sorted, lo.Reject(items, func(item T, index int) bool {
+		return lo.Contains(pinned, id(item))
+	})...)
+
+	return sorted
+}
+
+// visibleFolders returns the folders in the order they are listed.
+func (m model) visibleFolders() []FolderViewModel {
+	folders := searchFolders(filterFilesystemType(m.folders, m.filesystemFilter), m.search.Query())
+	if m.problemsOnly {
+		folders = problemFolders(folders)
+	}
+
+	folders = sortFolders(folders, m.sortMode)
+
+	return pinnedFirst(folders, m.settings.PinnedFolders, folderID)
+}
+
+// visibleDevices returns the listed devices, before grouping.
+func (m model) visibleDevices() []DeviceViewModel {
+	devices := searchDevices(
+		filterDevices(m.devices, m.deviceFilter, m.currentTime),
+		m.search.Query(),
+	)
+	if m.problemsOnly {
+		devices = problemDevices(devices, m.currentTime)
+	}
+
+	devices = sortDevices(devices, m.sortMode, m.currentTime)
+
+	return pinnedFirst(devices, m.settings.PinnedDevices, deviceID)
+}
+
+// selectableIDs lists the IDs of the selected section top to bottom.
+func (m model) selectableIDs() []string {
+	if m.selection.Section == SectionFolders {
+		return lo.Map(m.visibleFolders(), func(f FolderViewModel, index int) string {
+			return folderID(f)
+		})
+	}
+
+	devices := m.visibleDevices()
+	if m.settings.GroupDevices {
+		devices = lo.FlatMap(
+			groupDevices(devices, m.settings.DeviceGroups),
+			func(group lo.Tuple2[string, []DeviceViewModel], index int) []DeviceViewModel {
+				return group.B
+			},
+		)
+	}
+
+	return lo.Map(devices, func(d DeviceViewModel, index int) string {
+		return deviceID(d)
+	})
+}
+
+// moveSelection steps delta items from current, stopping at both ends. Without a
+// current item it starts from the top or the bottom.
+func moveSelection(ids []string, current string, delta int) string {
+	if len(ids) == 0 {
+		return ""
+	}
+
+	index := lo.IndexOf(ids, current)
+	if index == -1 {
+		return lo.Ternary(delta > 0, ids[0], ids[len(ids)-1])
+	}
+
+	return ids[max(0, min(index+delta, len(ids)-1))]
+}
+
+func toggleExpanded(expanded map[string]struct{}, id string) {
+	if _, exists := expanded[id]; exists {
+		delete(expanded, id)
+	} else {
+		expanded[id] = struct{}{}
+	}
+}
+
+func togglePin(pinned []string, id string) []string {
+	if lo.Contains(pinned, id) {
+		return lo.Without(pinned, id)
+	}
+
+	return append(append([]string{}, pinned...), id)
+}
+
+func folderID(folder FolderViewModel) string {
+	return folder.Config.ID
+}
+
+func deviceID(device DeviceViewModel) string {
+	return device.Config.DeviceID
+}
+
+func pinLabel(name string, pinned bool) string {
+	return lo.Ternary(pinned, "📌 "+name, name)
+}
+
+// selectedBorder keeps the status color but makes the selected card stand out.
+func selectedBorder(style lipgloss.Style, selected bool) lipgloss.Style {
+	if !selected {
+		return style
+	}
+
+	return style.Border(lipgloss.ThickBorder(), true)
+}
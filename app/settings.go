@@ -0,0 +1,137 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Settings are UI preferences persisted between runs.
+type Settings struct {
+	StatusCollapsed   bool     `json:"statusCollapsed"`
+	PendingSortByName bool     `json:"pendingSortByName"`
+	GroupDevices      bool     `json:"groupDevices"`
+	AbsoluteTimes     bool     `json:"absoluteTimes"`
+	ExactBytes        bool     `json:"exactBytes"`
+	FollowActivity    bool     `json:"followActivity"`
+	PinnedFolders     []string `json:"pinnedFolders"`
+	PinnedDevices     []string `json:"pinnedDevices"`
+	// local group of each device ID, kept out of the syncthing config
+	DeviceGroups map[string]string `json:"deviceGroups"`
+	// keyed by the syncthing address, see workspaceKey
+	Workspaces map[string]Workspace `json:"workspaces"`
+}
+
+func settingsPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "syncthing_TUI", "settings.json"), nil
+}
+
+// loadSettings returns the persisted settings, or the defaults when there are none.
+// A file that can't be read is reported, the defaults are used in its place.
+func loadSettings() (Settings, error) {
+	var settings Settings
+	path, err := settingsPath()
+	if err != nil {
+		return settings, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return settings, nil
+	}
+	if err != nil {
+		return settings, fmt.Errorf("reading settings: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return Settings{}, fmt.Errorf("invalid settings %s, changes won't be saved: %w", path, err)
+	}
+	return settings, nil
+}
+
+// saveSettings persists the settings of the model, unless they failed to load:
+// the defaults would overwrite what the user may still fix by hand.
+func (m model) saveSettings() tea.Cmd {
+	if m.settingsErr != nil {
+		return nil
+	}
+
+	return writeSettings(m.settings)
+}
+
+// settingsWrites orders the saves: the commands run concurrently and a save must
+// not overwrite a newer one that finished first.
+var settingsWrites struct {
+	sync.Mutex
+	queued  uint64
+	written uint64
+}
+
+func writeSettings(settings Settings) tea.Cmd {
+	settingsWrites.Lock()
+	settingsWrites.queued++
+	seq := settingsWrites.queued
+	settingsWrites.Unlock()
+
+	return func() tea.Msg {
+		settingsWrites.Lock()
+		defer settingsWrites.Unlock()
+		if seq < settingsWrites.written {
+			return nil
+		}
+
+		// not being able to persist preferences shouldn't interrupt the user
+		if err := writeSettingsFile(settings); err == nil {
+			settingsWrites.written = seq
+		}
+		return nil
+	}
+}
+
+// writeSettingsFile replaces the settings file in one rename, so it is never seen
+// half written.
+func writeSettingsFile(settings Settings) error {
+	path, err := settingsPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(dir, "settings-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(file.Name(), 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
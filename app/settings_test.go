@@ -0,0 +1,83 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteSettings(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	older := writeSettings(Settings{GroupDevices: true})
+	newer := writeSettings(Settings{ExactBytes: true})
+	// the newer save finishing first must win
+	newer()
+	older()
+
+	settings, err := loadSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !settings.ExactBytes || settings.GroupDevices {
+		t.Errorf("loadSettings() = %+v, want the newer save", settings)
+	}
+
+	path, err := settingsPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("settings dir has %d entries, want only the settings file", len(entries))
+	}
+}
+
+func TestLoadSettings(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    Settings
+		wantErr bool
+	}{
+		{name: "missing", want: Settings{}},
+		{name: "valid", content: `{"exactBytes": true}`, want: Settings{ExactBytes: true}},
+		{name: "corrupt", content: `{"exactBytes": tr`, wantErr: true},
+		{name: "empty", content: " ", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+			path, err := settingsPath()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.content != "" {
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := loadSettings()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadSettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.ExactBytes != tt.want.ExactBytes {
+				t.Errorf("loadSettings() = %+v, want %+v", got, tt.want)
+			}
+
+			// a file that failed to load is left for the user to fix
+			m := model{settings: got, settingsErr: err}
+			if cmd := m.saveSettings(); (cmd == nil) != tt.wantErr {
+				t.Errorf("saveSettings() = %v, want a save only after a clean load", cmd)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type SortMode int
+
+const (
+	// as listed in the syncthing config
+	SortByConfig SortMode = iota
+	SortByName
+	SortByStatus
+	SortByCompletion
+	SortBySize
+)
+
+var sortModes = []SortMode{SortByConfig, SortByName, SortByStatus, SortByCompletion, SortBySize}
+
+var sortKeys = key.NewBinding(
+	key.WithKeys("S"),
+	key.WithHelp("S", "change the sort order of folders and devices"),
+)
+
+func (s SortMode) Next() SortMode {
+	return sortModes[(slices.Index(sortModes, s)+1)%len(sortModes)]
+}
+
+func (s SortMode) String() string {
+	switch s {
+	case SortByName:
+		return "name"
+	case SortByStatus:
+		return "status"
+	case SortByCompletion:
+		return "completion"
+	case SortBySize:
+		return "size"
+	default:
+		return "config order"
+	}
+}
+
+// folders needing attention come first, paused and unknown ones last
+var folderStatusOrder = []FolderStatus{
+	Error,
+	FailedItems,
+	OutOfSync,
+	LocalAdditions,
+	LocalUnencrypted,
+	Syncing,
+	SyncPrepare,
+	Scanning,
+	Idle,
+	Unshared,
+	Paused,
+	Unknown,
+}
+
+// connected and syncing devices come first, then in sync, then disconnected
+var deviceStatusOrder = []DeviceStatus{
+	DeviceSyncing,
+	DeviceInSync,
+	DeviceUnusedInSync,
+	DevicePaused,
+	DeviceUnusedPaused,
+	DeviceDisconnected,
+	DeviceUnusedDisconnected,
+	DeviceDisconnectedInactive,
+	DeviceUnknown,
+}
+
+// folderCompletion is the synced share of the global bytes, an empty folder is
+// complete.
+func folderCompletion(folder FolderViewModel) float64 {
+	if folder.Status.GlobalBytes <= 0 {
+		return 100
+	}
+
+	synced := folder.Status.GlobalBytes - folder.Status.NeedBytes
+	return 100 * float64(synced) / float64(folder.Status.GlobalBytes)
+}
+
+func deviceCompletion(device DeviceViewModel) float64 {
+	completion := groupCompletion(device.StatusCompletion).Completion
+	if math.IsNaN(completion) {
+		return 100
+	}
+	return completion
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// sortFolders keeps the config order between folders that compare equal. The
+// least complete and the largest folders come first.
+func sortFolders(folders []FolderViewModel, mode SortMode) []FolderViewModel {
+	if mode == SortByConfig {
+		return folders
+	}
+
+	sorted := slices.Clone(folders)
+	slices.SortStableFunc(sorted, func(a, b FolderViewModel) int {
+		switch mode {
+		case SortByName:
+			return strings.Compare(strings.ToLower(folderName(a)), strings.ToLower(folderName(b)))
+		case SortByStatus:
+			return slices.Index(folderStatusOrder, folderStatus(a)) -
+				slices.Index(folderStatusOrder, folderStatus(b))
+		case SortByCompletion:
+			return compareFloat(folderCompletion(a), folderCompletion(b))
+		case SortBySize:
+			return compareFloat(float64(b.Status.GlobalBytes), float64(a.Status.GlobalBytes))
+		}
+		return 0
+	})
+
+	return sorted
+}
+
+// sortDevices sorts by size on the bytes the devices share with this one.
+func sortDevices(
+	devices []DeviceViewModel,
+	mode SortMode,
+	currentTime time.Time,
+) []DeviceViewModel {
+	if mode == SortByConfig {
+		return devices
+	}
+
+	sorted := slices.Clone(devices)
+	slices.SortStableFunc(sorted, func(a, b DeviceViewModel) int {
+		switch mode {
+		case SortByName:
+			return strings.Compare(strings.ToLower(a.Config.Name), strings.ToLower(b.Config.Name))
+		case SortByStatus:
+			return slices.Index(deviceStatusOrder, deviceStatus(a, currentTime)) -
+				slices.Index(deviceStatusOrder, deviceStatus(b, currentTime))
+		case SortByCompletion:
+			return compareFloat(deviceCompletion(a), deviceCompletion(b))
+		case SortBySize:
+			return compareFloat(
+				float64(groupCompletion(b.StatusCompletion).TotalBytes),
+				float64(groupCompletion(a.StatusCompletion).TotalBytes),
+			)
+		}
+		return 0
+	})
+
+	return sorted
+}
+
+func viewSortMode(mode SortMode) string {
+	if mode == SortByConfig {
+		return ""
+	}
+
+	return lipgloss.NewStyle().Italic(true).Faint(true).Padding(0, 1).Render(
+		fmt.Sprintf("sorted by %s (S to change)", mode))
+}
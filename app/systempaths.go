@@ -0,0 +1,152 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+)
+
+const SYSTEM_PATH_MARK = "system-path-"
+
+type systemPath struct {
+	// key in the /rest/system/paths answer
+	key   string
+	label string
+}
+
+// systemPaths are the locations worth knowing for a backup or a bug report, the
+// others are derived from them.
+var systemPaths = []systemPath{
+	{key: "config", label: "Config"},
+	{key: "database", label: "Database"},
+	{key: "logFile", label: "Log"},
+	{key: "defFolder", label: "Default Folder"},
+}
+
+type FetchedSystemPathsMsg struct {
+	paths map[string]string
+	err   error
+}
+
+// fetchSystemPaths is asked once, syncthing only changes its paths on restart.
+func fetchSystemPaths(httpData HttpData) tea.Cmd {
+	return func() tea.Msg {
+		var paths map[string]string
+		err := fetchOptionalBytes(httpData, *httpData.url.JoinPath(SYSTEM_PATHS), &paths)
+		return FetchedSystemPathsMsg{paths: paths, err: err}
+	}
+}
+
+// truncateStart keeps the end of the path, where the file name is.
+func truncateStart(s string, width int) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+		runes = runes[1:]
+	}
+	return "…" + string(runes)
+}
+
+// viewSystemPaths lists the paths of the this-device card, a click on one shows
+// it in full.
+func viewSystemPaths(paths map[string]string, width int) string {
+	const labelWidth = 16
+	lines := []string{lipgloss.NewStyle().PaddingTop(1).Bold(true).Render("System Paths")}
+	for _, p := range systemPaths {
+		path, has := paths[p.key]
+		if !has || path == "" {
+			continue
+		}
+		row := lipgloss.JoinHorizontal(lipgloss.Top,
+			lipgloss.NewStyle().Width(labelWidth).Render(p.label),
+			truncateStart(path, width-labelWidth),
+		)
+		lines = append(lines, zone.Mark(SYSTEM_PATH_MARK+p.key, row))
+	}
+	if len(lines) == 1 {
+		return ""
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// SystemPathModel shows a path too long for the this-device card.
+type SystemPathModel struct {
+	Show  bool
+	label string
+	path  string
+	// terminal width
+	width      int
+	zonePrefix string
+}
+
+// NewSystemPath finds the path from its mark in the this-device card.
+func NewSystemPath(paths map[string]string, mark string, width int) SystemPathModel {
+	for _, p := range systemPaths {
+		if mark == SYSTEM_PATH_MARK+p.key {
+			return SystemPathModel{
+				Show:       true,
+				label:      p.label,
+				path:       paths[p.key],
+				width:      width,
+				zonePrefix: zone.NewPrefix(),
+			}
+		}
+	}
+
+	return SystemPathModel{}
+}
+
+func (m SystemPathModel) Update(msg tea.Msg) (SystemPathModel, tea.Cmd) {
+	if !m.Show {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q", "enter":
+			m.Show = false
+		}
+	case tea.MouseMsg:
+		if msg.Action != tea.MouseActionRelease || msg.Button != tea.MouseButtonLeft {
+			return m, nil
+		}
+
+		if zone.Get(m.zonePrefix+"close").InBounds(msg) ||
+			!zone.Get(m.zonePrefix+"area").InBounds(msg) {
+			m.Show = false
+		}
+	}
+
+	return m, nil
+}
+
+func (m SystemPathModel) View() string {
+	width := min(max(lipgloss.Width(m.path), 30)+2, max(30, m.width-4))
+	header := lipgloss.NewStyle().
+		Padding(0, 1).
+		Width(width).
+		Background(styles.AccentColor).
+		Render(fmt.Sprintf("%s Path", m.label))
+	body := lipgloss.NewStyle().
+		Padding(1, 1).
+		Width(width).
+		Render(m.path)
+	actions := lipgloss.PlaceHorizontal(width, lipgloss.Right,
+		zone.Mark(m.zonePrefix+"close", styles.BtnStyleV2.Render("Close")))
+
+	return zone.Mark(
+		m.zonePrefix+"area",
+		lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Render(
+			lipgloss.JoinVertical(lipgloss.Left, header, body, actions),
+		),
+	)
+}
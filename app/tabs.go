@@ -39,3 +39,12 @@ var (
 		BorderLeft(false).
 		BorderRight(false)
 )
+
+func setTabHighlight(color lipgloss.TerminalColor) {
+	tab = tab.BorderForeground(color)
+	activeTab = tab.Border(activeTabBorder, true)
+	tabGap = tab.
+		BorderTop(false).
+		BorderLeft(false).
+		BorderRight(false)
+}
@@ -0,0 +1,218 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+	"github.com/pdrolopes/syncthing_TUI/syncthing"
+)
+
+const (
+	EVENT_LOG_SIZE      = 500
+	TIMELINE_VISIBLE    = 20
+	TIMELINE_ROW_LAYOUT = "15:04:05"
+)
+
+type TimelineSince int
+
+const (
+	SinceAll TimelineSince = iota
+	SinceLastHour
+	SinceToday
+)
+
+type TimelineKind int
+
+const (
+	KindAll TimelineKind = iota
+	KindErrors
+	KindTransfers
+	KindConfig
+)
+
+type EventTimelineModel struct {
+	Show  bool
+	since TimelineSince
+	kind  TimelineKind
+}
+
+func appendEventLog(
+	log []syncthing.Event[any],
+	events []syncthing.Event[any],
+) []syncthing.Event[any] {
+	log = append(log, events...)
+	if len(log) > EVENT_LOG_SIZE {
+		log = log[len(log)-EVENT_LOG_SIZE:]
+	}
+
+	return log
+}
+
+func (m EventTimelineModel) Update(msg tea.KeyMsg) EventTimelineModel {
+	switch msg.String() {
+	case "esc", "q", "e":
+		m.Show = false
+	case "t":
+		m.since = (m.since + 1) % (SinceToday + 1)
+	case "f":
+		m.kind = (m.kind + 1) % (KindConfig + 1)
+	}
+
+	return m
+}
+
+func sinceLabel(since TimelineSince) string {
+	switch since {
+	case SinceAll:
+		return "All time"
+	case SinceLastHour:
+		return "Last hour"
+	case SinceToday:
+		return "Today"
+	}
+
+	return ""
+}
+
+func kindLabel(kind TimelineKind) string {
+	switch kind {
+	case KindAll:
+		return "All events"
+	case KindErrors:
+		return "Errors"
+	case KindTransfers:
+		return "Transfers"
+	case KindConfig:
+		return "Config changes"
+	}
+
+	return ""
+}
+
+func eventKind(e syncthing.Event[any]) TimelineKind {
+	switch data := e.Data.(type) {
+	case syncthing.Config:
+		return KindConfig
+	case syncthing.FolderCompletionEventData, syncthing.FolderScanProgressEventData:
+		return KindTransfers
+	case syncthing.DeviceDisconnectedEventData:
+		return KindErrors
+	case syncthing.FolderWatchStateChangedEventData:
+		if data.To != "" {
+			return KindErrors
+		}
+	case syncthing.StateChangedEventData:
+		if data.To == "error" {
+			return KindErrors
+		}
+		if data.To == "syncing" {
+			return KindTransfers
+		}
+	}
+
+	switch e.Type {
+	case "FolderErrors", "Failure", "DeviceRejected", "FolderRejected":
+		return KindErrors
+	case "ItemStarted", "ItemFinished", "DownloadProgress", "RemoteDownloadProgress":
+		return KindTransfers
+	}
+
+	return KindAll
+}
+
+func matchesTimeline(
+	e syncthing.Event[any],
+	since TimelineSince,
+	kind TimelineKind,
+	currentTime time.Time,
+) bool {
+	switch since {
+	case SinceLastHour:
+		if e.Time.Before(currentTime.Add(-time.Hour)) {
+			return false
+		}
+	case SinceToday:
+		year, month, day := currentTime.Date()
+		if e.Time.Before(time.Date(year, month, day, 0, 0, 0, 0, currentTime.Location())) {
+			return false
+		}
+	case SinceAll:
+	}
+
+	return kind == KindAll || eventKind(e) == kind
+}
+
+func eventSummary(e syncthing.Event[any]) string {
+	switch data := e.Data.(type) {
+	case syncthing.FolderSummaryEventData:
+		return fmt.Sprintf("folder %s is %s", data.Folder, data.Summary.State)
+	case syncthing.Config:
+		return "configuration saved"
+	case syncthing.FolderScanProgressEventData:
+		return fmt.Sprintf("scanning %s (%d/%d)", data.Folder, data.Current, data.Total)
+	case syncthing.StateChangedEventData:
+		return fmt.Sprintf("folder %s: %s → %s", data.Folder, data.From, data.To)
+	case syncthing.FolderCompletionEventData:
+		return fmt.Sprintf("%s completion of %s: %.0f%%",
+			shortIdentification(data.Device), data.Folder, data.Completion)
+	case syncthing.PendingDevicesChangedEventData:
+		return fmt.Sprintf("pending devices: +%d -%d", len(data.Added), len(data.Removed))
+	case syncthing.DeviceConnectedEventData:
+		return fmt.Sprintf("%s connected from %s", data.DeviceName, data.Addr)
+	case syncthing.DeviceDisconnectedEventData:
+		return fmt.Sprintf("%s disconnected: %s", shortIdentification(data.ID), data.Error)
+	case syncthing.FolderWatchStateChangedEventData:
+		if data.To == "" {
+			return fmt.Sprintf("folder %s is watching for changes again", data.Folder)
+		}
+		return fmt.Sprintf("folder %s watcher failed: %s", data.Folder, data.To)
+	}
+
+	return ""
+}
+
+func (m EventTimelineModel) View(log []syncthing.Event[any], currentTime time.Time) string {
+	const width = 80
+	header := lipgloss.NewStyle().
+		Padding(0, 1).
+		Width(width).
+		Background(styles.AccentColor).
+		Render("Events")
+	filters := lipgloss.NewStyle().Padding(0, 1).Width(width).Render(fmt.Sprintf(
+		"[t] %s   [f] %s   [esc] close",
+		sinceLabel(m.since),
+		kindLabel(m.kind),
+	))
+
+	rows := make([]string, 0, TIMELINE_VISIBLE)
+	typeStyle := lipgloss.NewStyle().Bold(true)
+	for i := len(log) - 1; i >= 0 && len(rows) < TIMELINE_VISIBLE; i-- {
+		e := log[i]
+		if !matchesTimeline(e, m.since, m.kind, currentTime) {
+			continue
+		}
+		rows = append(rows, lipgloss.NewStyle().Width(width-2).MaxHeight(1).Render(
+			strings.Join([]string{
+				e.Time.Format(TIMELINE_ROW_LAYOUT),
+				typeStyle.Render(e.Type),
+				eventSummary(e),
+			}, " "),
+		))
+	}
+	if len(rows) == 0 {
+		rows = append(rows, "No events")
+	}
+
+	body := lipgloss.NewStyle().
+		Padding(1, 1).
+		Width(width).
+		Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+
+	return lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Render(
+		lipgloss.JoinVertical(lipgloss.Left, header, filters, body),
+	)
+}
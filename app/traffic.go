@@ -0,0 +1,312 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+)
+
+const (
+	TRAFFIC_HISTORY_SIZE = 40
+	// share of a rate limit above which the limit is considered hit
+	RATE_LIMIT_THRESHOLD = 0.9
+	// rates are sampled on every connections refresh, a shorter window smooths nothing
+	RATE_WINDOW_MIN = REFETCH_STATUS_INTERVAL
+)
+
+type ViewMode int
+
+const (
+	ViewDefault ViewMode = iota
+	ViewTraffic
+)
+
+func ParseViewMode(view string) (ViewMode, error) {
+	switch view {
+	case "", "default":
+		return ViewDefault, nil
+	case "traffic":
+		return ViewTraffic, nil
+	}
+
+	return ViewDefault, fmt.Errorf("unknown view %q, expected \"default\" or \"traffic\"", view)
+}
+
+// ValidateRateWindow accepts no smoothing at all, or a window spanning at least
+// one refresh.
+func ValidateRateWindow(window time.Duration) error {
+	if window != 0 && window < RATE_WINDOW_MIN {
+		return fmt.Errorf("rate window %s is too short, expected 0 or at least %s",
+			window, RATE_WINDOW_MIN)
+	}
+
+	return nil
+}
+
+// smoothRate is an exponentially weighted moving average of the rates, where
+// samples older than the window weigh little. Without a window the rate of the
+// last refresh is shown as is. Negative samples come from reset counters and
+// count as no traffic.
+func smoothRate(previous, sample int64, elapsed, window time.Duration) int64 {
+	previous, sample = max(previous, 0), max(sample, 0)
+	if window <= 0 || elapsed <= 0 {
+		return sample
+	}
+
+	weight := 1 - math.Exp(-elapsed.Seconds()/window.Seconds())
+	return previous + int64(math.Round(weight*float64(sample-previous)))
+}
+
+// TrafficSample is a snapshot of the total throughput at one connections refresh.
+type TrafficSample struct {
+	InBytesPerSecond  int64
+	OutBytesPerSecond int64
+}
+
+func appendTrafficSample(history []TrafficSample, sample TrafficSample) []TrafficSample {
+	history = append(history, sample)
+	if len(history) > TRAFFIC_HISTORY_SIZE {
+		history = history[len(history)-TRAFFIC_HISTORY_SIZE:]
+	}
+
+	return history
+}
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+func sparkline(values []int64) string {
+	var highest int64
+	for _, v := range values {
+		highest = max(highest, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		if highest == 0 {
+			b.WriteRune(sparkBlocks[0])
+			continue
+		}
+		index := int(max(v, 0) * int64(len(sparkBlocks)-1) / highest)
+		b.WriteRune(sparkBlocks[index])
+	}
+
+	return b.String()
+}
+
+// colors of the devices in the bandwidth allocation bars
+var allocationPalette = []lipgloss.Color{
+	"#5fafff", "#87d787", "#ffaf5f", "#d787d7", "#ff5f87", "#5fd7d7", "#d7d75f", "#af87ff",
+}
+
+// allocationCells splits width cells between rates proportionally, handing the
+// rounding leftovers to the largest remainders so the cells always add up.
+func allocationCells(rates []int64, width int) []int {
+	var total int64
+	for _, rate := range rates {
+		total += max(rate, 0)
+	}
+
+	cells := make([]int, len(rates))
+	if total == 0 {
+		return cells
+	}
+
+	remainders := make([]int, len(rates))
+	used := 0
+	for i, rate := range rates {
+		share := max(rate, 0) * int64(width)
+		cells[i] = int(share / total)
+		remainders[i] = i
+		used += cells[i]
+	}
+	sort.SliceStable(remainders, func(a, b int) bool {
+		ra := max(rates[remainders[a]], 0) * int64(width) % total
+		rb := max(rates[remainders[b]], 0) * int64(width) % total
+		return ra > rb
+	})
+	for i := 0; used < width && i < len(remainders); i++ {
+		cells[remainders[i]]++
+		used++
+	}
+
+	return cells
+}
+
+func allocationBar(rates []int64, width int) string {
+	cells := allocationCells(rates, width)
+	var b strings.Builder
+	used := 0
+	for i, n := range cells {
+		color := allocationPalette[i%len(allocationPalette)]
+		b.WriteString(lipgloss.NewStyle().Foreground(color).Render(strings.Repeat("█", n)))
+		used += n
+	}
+	b.WriteString(lipgloss.NewStyle().Faint(true).Render(strings.Repeat("░", max(0, width-used))))
+
+	return b.String()
+}
+
+func rateShare(rate, total int64) string {
+	if total <= 0 {
+		return "0%"
+	}
+
+	return fmt.Sprintf("%d%%", max(rate, 0)*100/total)
+}
+
+// limitLabel flags a direction whose throughput is close to its rate limit.
+func limitLabel(rate int64, limitKbps int) string {
+	if limitKbps <= 0 {
+		return ""
+	}
+
+	limit := int64(limitKbps) * 1024
+	if float64(rate) < float64(limit)*RATE_LIMIT_THRESHOLD {
+		return ""
+	}
+
+	return lipgloss.NewStyle().
+		Foreground(styles.WarningColor).
+		Render(fmt.Sprintf(" ⚠ limit %s/s", humanBytes(limit)))
+}
+
+// viewAllocation shows how the current bandwidth is shared between the devices
+// transferring, as stacked bars as wide as the terminal.
+func viewAllocation(this ThisDeviceStatus, sorted []DeviceViewModel, width int) string {
+	container := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		PaddingLeft(1).
+		PaddingRight(1).
+		Width(max(width-2, 40))
+	innerWidth := container.GetWidth() - container.GetHorizontalPadding()
+	boldStyle := lipgloss.NewStyle().Bold(true)
+
+	active := make([]DeviceViewModel, 0, len(sorted))
+	for _, device := range sorted {
+		if device.InGoingBytesPerSecond > 0 || device.OutGoingBytesPerSecond > 0 {
+			active = append(active, device)
+		}
+	}
+
+	inRates := make([]int64, len(active))
+	outRates := make([]int64, len(active))
+	var inTotal, outTotal int64
+	for i, device := range active {
+		inRates[i] = device.InGoingBytesPerSecond
+		outRates[i] = device.OutGoingBytesPerSecond
+		inTotal += max(device.InGoingBytesPerSecond, 0)
+		outTotal += max(device.OutGoingBytesPerSecond, 0)
+	}
+
+	const labelWidth = 3
+	barWidth := innerWidth - labelWidth
+	rows := []string{
+		boldStyle.Render("Bandwidth Allocation"),
+		"",
+		"↓  " + allocationBar(inRates, barWidth),
+		"↑  " + allocationBar(outRates, barWidth),
+		"",
+	}
+
+	if len(active) == 0 {
+		rows = append(rows, "No active transfers")
+	}
+	for i, device := range active {
+		color := allocationPalette[i%len(allocationPalette)]
+		rows = append(rows, fmt.Sprintf("%s %s  ↓ %s  ↑ %s",
+			lipgloss.NewStyle().Foreground(color).Render("■"),
+			device.Config.Name,
+			rateShare(device.InGoingBytesPerSecond, inTotal),
+			rateShare(device.OutGoingBytesPerSecond, outTotal),
+		))
+	}
+	// MaxRecvKbps limits downloads and MaxSendKbps uploads
+	rows = append(rows, "", fmt.Sprintf("Total ↓ %s/s%s  ↑ %s/s%s",
+		humanBytes(inTotal),
+		limitLabel(this.InGoingBytesPerSecond, this.MaxRecvKbps),
+		humanBytes(outTotal),
+		limitLabel(this.OutGoingBytesPerSecond, this.MaxSendKbps),
+	))
+
+	return container.Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
+func viewTraffic(
+	this ThisDeviceStatus,
+	devices []DeviceViewModel,
+	history []TrafficSample,
+	terminalWidth int,
+) string {
+	const width = 70
+	container := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		PaddingLeft(1).
+		PaddingRight(1).
+		Width(width)
+	innerWidth := container.GetWidth() - container.GetHorizontalPadding()
+	boldStyle := lipgloss.NewStyle().Bold(true)
+
+	inHistory := make([]int64, 0, len(history))
+	outHistory := make([]int64, 0, len(history))
+	for _, sample := range history {
+		inHistory = append(inHistory, sample.InBytesPerSecond)
+		outHistory = append(outHistory, sample.OutBytesPerSecond)
+	}
+
+	totals := spaceAroundTable().
+		Width(innerWidth).
+		Row(
+			"Download rate",
+			fmt.Sprintf("%s/s (%s)",
+				humanBytes(this.InGoingBytesPerSecond),
+				humanBytes(this.InBytesTotal),
+			),
+		).
+		Row("", lipgloss.NewStyle().Foreground(styles.SuccessColor).Render(sparkline(inHistory))).
+		Row(
+			"Upload rate",
+			fmt.Sprintf("%s/s (%s)",
+				humanBytes(this.OutGoingBytesPerSecond),
+				humanBytes(this.OutBytesTotal),
+			),
+		).
+		Row("", lipgloss.NewStyle().Foreground(styles.AccentColor).Render(sparkline(outHistory)))
+
+	sorted := make([]DeviceViewModel, len(devices))
+	copy(sorted, devices)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].InGoingBytesPerSecond+sorted[i].OutGoingBytesPerSecond >
+			sorted[j].InGoingBytesPerSecond+sorted[j].OutGoingBytesPerSecond
+	})
+
+	perDevice := spaceAroundTable().Width(innerWidth)
+	for _, device := range sorted {
+		if !device.Connection.B.Connected {
+			continue
+		}
+		perDevice = perDevice.Row(
+			device.Config.Name,
+			fmt.Sprintf("↓ %s/s ↑ %s/s",
+				humanBytes(device.InGoingBytesPerSecond),
+				humanBytes(device.OutGoingBytesPerSecond),
+			),
+		)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		container.Render(lipgloss.JoinVertical(lipgloss.Left,
+			boldStyle.Render("Traffic"),
+			"",
+			totals.Render(),
+			"",
+			boldStyle.Render("Connected Devices"),
+			perDevice.Render(),
+		)),
+		viewAllocation(this, sorted, terminalWidth),
+	)
+}
@@ -0,0 +1,111 @@
+package app
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int64
+		want   string
+	}{
+		{name: "empty", values: nil, want: ""},
+		{name: "all zero", values: []int64{0, 0, 0}, want: "▁▁▁"},
+		{name: "rising", values: []int64{0, 7, 14}, want: "▁▄█"},
+		{name: "negative", values: []int64{-100, 50, 100}, want: "▁▄█"},
+		{name: "only negative", values: []int64{-1, -7}, want: "▁▁"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sparkline(tt.values); got != tt.want {
+				t.Errorf("sparkline(%v) = %q, want %q", tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSmoothRate(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous int64
+		sample   int64
+		elapsed  time.Duration
+		window   time.Duration
+		want     int64
+	}{
+		{name: "no window", previous: 10, sample: 100, elapsed: time.Second, want: 100},
+		{name: "no elapsed time", previous: 10, sample: 100, window: time.Minute, want: 100},
+		{
+			name:     "window",
+			previous: 0,
+			sample:   1000,
+			elapsed:  10 * time.Second,
+			window:   10 * time.Second,
+			want:     632,
+		},
+		{name: "negative sample", previous: 10, sample: -500, want: 0},
+		{
+			name:     "negative sample in a window",
+			previous: 1000,
+			sample:   -1000,
+			elapsed:  10 * time.Second,
+			window:   10 * time.Second,
+			want:     368,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := smoothRate(tt.previous, tt.sample, tt.elapsed, tt.window)
+			if got != tt.want {
+				t.Errorf("smoothRate() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestByteThroughputInSeconds(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		before TotalBytes
+		after  TotalBytes
+		want   int64
+	}{
+		{
+			name:   "first sample",
+			before: TotalBytes{},
+			after:  TotalBytes{bytes: 1000, at: start.Add(10 * time.Second)},
+			want:   0,
+		},
+		{
+			name:   "steady",
+			before: TotalBytes{bytes: 1000, at: start},
+			after:  TotalBytes{bytes: 2000, at: start.Add(10 * time.Second)},
+			want:   100,
+		},
+		{
+			name:   "same time",
+			before: TotalBytes{bytes: 1000, at: start},
+			after:  TotalBytes{bytes: 2000, at: start},
+			want:   0,
+		},
+		{
+			name:   "counter reset",
+			before: TotalBytes{bytes: 100000, at: start},
+			after:  TotalBytes{bytes: 10, at: start.Add(10 * time.Second)},
+			want:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := byteThroughputInSeconds(tt.before, tt.after); got != tt.want {
+				t.Errorf("byteThroughputInSeconds() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+	"github.com/pdrolopes/syncthing_TUI/syncthing"
+	"github.com/samber/lo"
+)
+
+// how many finished items are listed, ongoing ones are capped to the same size
+const RECENT_TRANSFERS_SIZE = 20
+
+// Transfer is a file or directory syncthing is pulling or has pulled.
+type Transfer struct {
+	Folder string
+	Item   string
+	Action string
+	Err    string
+	At     time.Time
+}
+
+func (t Transfer) sameItem(other Transfer) bool {
+	return t.Folder == other.Folder && t.Item == other.Item
+}
+
+// Transfers follows the ItemStarted and ItemFinished events.
+type Transfers struct {
+	// ongoing, latest first
+	Active []Transfer
+	// finished, latest first
+	Recent []Transfer
+}
+
+func (t Transfers) Started(data syncthing.ItemStartedEventData, at time.Time) Transfers {
+	transfer := Transfer{Folder: data.Folder, Item: data.Item, Action: data.Action, At: at}
+	t.Active = prependTransfer(t.Active, transfer)
+	return t
+}
+
+func (t Transfers) Finished(data syncthing.ItemFinishedEventData, at time.Time) Transfers {
+	transfer := Transfer{Folder: data.Folder, Item: data.Item, Action: data.Action, At: at}
+	if data.Error != nil {
+		transfer.Err = *data.Error
+	}
+	t.Active = lo.Reject(t.Active, func(active Transfer, index int) bool {
+		return active.sameItem(transfer)
+	})
+	t.Recent = prependTransfer(t.Recent, transfer)
+	return t
+}
+
+func prependTransfer(list []Transfer, transfer Transfer) []Transfer {
+	list = append([]Transfer{transfer}, lo.Reject(list, func(t Transfer, index int) bool {
+		return t.sameItem(transfer)
+	})...)
+	if len(list) > RECENT_TRANSFERS_SIZE {
+		list = list[:RECENT_TRANSFERS_SIZE]
+	}
+
+	return list
+}
+
+func viewTransfers(
+	transfers Transfers,
+	folders []FolderViewModel,
+	currentTime time.Time,
+	timeStyle TimeStyle,
+) string {
+	if len(transfers.Active)+len(transfers.Recent) == 0 {
+		return ""
+	}
+
+	container := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		PaddingRight(1).
+		PaddingLeft(1).
+		Width(50)
+	innerWidth := container.GetWidth() - container.GetHorizontalPadding()
+	folderLabel := func(folderID string) string {
+		folder, found := lo.Find(folders, func(f FolderViewModel) bool {
+			return f.Config.ID == folderID
+		})
+		return lo.Ternary(found, folderName(folder), folderID)
+	}
+
+	detail := lipgloss.NewStyle().Faint(true)
+	rows := spaceAroundTable().Width(innerWidth)
+	for _, t := range transfers.Active {
+		rows = rows.Row(
+			"⇣ "+filepath.Base(t.Item),
+			detail.Render(folderLabel(t.Folder)+", "+t.Action+", in progress"),
+		)
+	}
+	for _, t := range transfers.Recent {
+		name := filepath.Base(t.Item)
+		if t.Err != "" {
+			name = lipgloss.NewStyle().Foreground(styles.ErrorColor).Render("✗ " + name)
+		}
+		rows = rows.Row(name, detail.Render(
+			folderLabel(t.Folder)+", "+t.Action+", "+FormatInstant(t.At, currentTime, timeStyle),
+		))
+	}
+
+	header := lipgloss.NewStyle().Bold(true).Render("Recent Changes")
+	return container.Render(lipgloss.JoinVertical(lipgloss.Left, header, rows.Render()))
+}
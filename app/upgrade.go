@@ -0,0 +1,215 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/pdrolopes/syncthing_TUI/styles"
+	"github.com/pdrolopes/syncthing_TUI/syncthing"
+)
+
+const (
+	UPGRADE_MODAL_AREA  = "upgrade-modal"
+	UPGRADE_BTN         = "upgrade-syncthing"
+	UPGRADE_CONFIRM_BTN = "confirm-upgrade"
+	UPGRADE_CANCEL_BTN  = "cancel-upgrade"
+	// syncthing checks the releases on its own, no need to ask often
+	REFETCH_UPGRADE_INTERVAL = time.Hour
+	// time given to syncthing to download the release and restart
+	UPGRADE_RESTART_DELAY = 30 * time.Second
+)
+
+// ErrUpgradeUnsupported is returned when syncthing was built without upgrades,
+// e.g. by a distribution package.
+var ErrUpgradeUnsupported = errors.New("upgrades are unsupported by this syncthing build")
+
+type Upgrade struct {
+	ShowConfirm bool
+	Upgrading   bool
+	Unsupported bool
+	Release     syncthing.SystemUpgrade
+}
+
+func (u Upgrade) Available() bool {
+	return !u.Upgrading && (u.Release.Newer || u.Release.MajorNewer)
+}
+
+type FetchedSystemUpgradeMsg struct {
+	upgrade syncthing.SystemUpgrade
+	err     error
+}
+
+type UpgradedSystemMsg struct {
+	err error
+}
+
+func fetchSystemUpgrade(httpData HttpData) tea.Cmd {
+	return func() tea.Msg {
+		url := httpData.url.JoinPath(SYSTEM_UPGRADE)
+		req, err := http.NewRequest(http.MethodGet, url.String(), nil)
+		if err != nil {
+			return FetchedSystemUpgradeMsg{err: err}
+		}
+
+		httpData.authorize(req)
+		resp, err := httpData.client.Do(req)
+		if err != nil {
+			return FetchedSystemUpgradeMsg{err: err}
+		}
+		defer resp.Body.Close()
+
+		switch resp.StatusCode {
+		case http.StatusOK:
+		case http.StatusNotFound:
+			return FetchedSystemUpgradeMsg{err: ErrEndpointUnavailable}
+		case http.StatusServiceUnavailable, http.StatusNotImplemented:
+			return FetchedSystemUpgradeMsg{err: ErrUpgradeUnsupported}
+		default:
+			// e.g. syncthing can't reach the releases server
+			return FetchedSystemUpgradeMsg{
+				err: fmt.Errorf("GET %s failed: %s", url.Path, resp.Status),
+			}
+		}
+
+		var upgrade syncthing.SystemUpgrade
+		if err := json.NewDecoder(resp.Body).Decode(&upgrade); err != nil {
+			return FetchedSystemUpgradeMsg{err: fmt.Errorf("error unmarshalling JSON: %w", err)}
+		}
+
+		return FetchedSystemUpgradeMsg{upgrade: upgrade}
+	}
+}
+
+// upgradeSystem makes syncthing download the latest release and restart with it.
+func upgradeSystem(httpData HttpData) tea.Cmd {
+	return func() tea.Msg {
+		url := httpData.url.JoinPath(SYSTEM_UPGRADE)
+		req, err := http.NewRequest(http.MethodPost, url.String(), nil)
+		if err != nil {
+			return UpgradedSystemMsg{err: err}
+		}
+
+		httpData.authorize(req)
+		resp, err := httpData.client.Do(req)
+		if err != nil {
+			return UpgradedSystemMsg{err: err}
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return UpgradedSystemMsg{err: fmt.Errorf("upgrade failed with status %s", resp.Status)}
+		}
+
+		return UpgradedSystemMsg{}
+	}
+}
+
+func autoUpgradeLabel(intervalH int, upgrade Upgrade) string {
+	switch {
+	case upgrade.Unsupported:
+		return "unavailable in this build"
+	case intervalH <= 0:
+		return "disabled"
+	}
+
+	return fmt.Sprintf("every %dh", intervalH)
+}
+
+func viewUpgradeRow(upgrade Upgrade) string {
+	if upgrade.Upgrading {
+		return fmt.Sprintf("upgrading to %s…", upgrade.Release.Latest)
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.NewStyle().
+			Foreground(styles.WarningColor).
+			Render(fmt.Sprintf("⬆ %s ", upgrade.Release.Latest)),
+		zone.Mark(UPGRADE_BTN, styles.BtnStyleV2.Render("Upgrade")),
+	)
+}
+
+func viewConfirmUpgrade(upgrade Upgrade) string {
+	width := 60
+	header := lipgloss.NewStyle().
+		Padding(1, 1).
+		Width(width).
+		Background(styles.WarningColor).
+		Render("Upgrade Syncthing")
+	text := fmt.Sprintf(
+		"Syncthing will upgrade from %s to %s and restart. "+
+			"Transfers in progress are interrupted.",
+		upgrade.Release.Running,
+		upgrade.Release.Latest,
+	)
+	if upgrade.Release.MajorNewer {
+		text += "\n\nThis is a new major version, " +
+			"every device must be upgraded to keep syncing with this one."
+	}
+	body := lipgloss.NewStyle().Padding(1, 1).Width(width).Render(text + "\n\nUpgrade now?")
+	var actions string
+	{
+		layout := lipgloss.NewStyle().Padding(0, 1).Width(width)
+		btnConfirm := zone.Mark(UPGRADE_CONFIRM_BTN, styles.BtnStyleV2.Render("Upgrade"))
+		btnCancel := zone.Mark(UPGRADE_CANCEL_BTN, styles.BtnStyleV2.Render("Cancel"))
+		actions = viewFooter(
+			layout.GetWidth()-layout.GetHorizontalPadding(),
+			[]string{btnConfirm},
+			[]string{btnCancel},
+		)
+		actions = layout.Render(actions)
+	}
+
+	return zone.Mark(
+		UPGRADE_MODAL_AREA,
+		lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Render(
+			lipgloss.JoinVertical(lipgloss.Left, header, body, actions),
+		),
+	)
+}
+
+func (m model) startUpgrade() (model, tea.Cmd) {
+	m.upgrade.ShowConfirm = false
+	if !m.upgrade.Available() {
+		return m, nil
+	}
+
+	m.upgrade.Upgrading = true
+	return m, upgradeSystem(m.httpData)
+}
+
+func handleMouseEventsUpgradeModal(m model, msg tea.MouseMsg) (model, tea.Cmd) {
+	if msg.Action != tea.MouseActionRelease || msg.Button != tea.MouseButtonLeft {
+		return m, nil
+	}
+
+	if zone.Get(UPGRADE_CONFIRM_BTN).InBounds(msg) {
+		return m.startUpgrade()
+	}
+
+	// cancel button or click out of modal bounds
+	if zone.Get(UPGRADE_CANCEL_BTN).InBounds(msg) ||
+		!zone.Get(UPGRADE_MODAL_AREA).InBounds(msg) {
+		m.upgrade.ShowConfirm = false
+	}
+
+	return m, nil
+}
+
+func handleKeyBoardEventsUpgradeModal(m model, msg tea.KeyMsg) (model, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "y":
+		return m.startUpgrade()
+	case "esc", "n":
+		m.upgrade.ShowConfirm = false
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	}
+
+	return m, nil
+}
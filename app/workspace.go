@@ -0,0 +1,58 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Workspace is how the dashboard of one syncthing instance was left: its sort
+// order, filters and view. Preferences like the time and size formats are
+// shared by every instance and stay in Settings.
+type Workspace struct {
+	SortMode         SortMode     `json:"sortMode"`
+	DeviceFilter     DeviceFilter `json:"deviceFilter"`
+	FilesystemFilter string       `json:"filesystemFilter"`
+	ProblemsOnly     bool         `json:"problemsOnly"`
+	View             ViewMode     `json:"view"`
+}
+
+// workspaceKey is the address given for the instance, it is known before
+// syncthing answers, unlike its device ID.
+func workspaceKey(httpData HttpData) string {
+	return httpData.url.String()
+}
+
+// restoreWorkspace applies the remembered workspace, the command line options
+// win when they ask for more than the defaults.
+func (m model) restoreWorkspace(options Options) model {
+	workspace, has := m.settings.Workspaces[workspaceKey(m.httpData)]
+	if !has {
+		return m
+	}
+
+	m.sortMode = workspace.SortMode
+	m.deviceFilter = workspace.DeviceFilter
+	m.filesystemFilter = workspace.FilesystemFilter
+	m.problemsOnly = options.ProblemsOnly || workspace.ProblemsOnly
+	if options.View == ViewDefault {
+		m.viewMode = workspace.View
+	}
+	return m
+}
+
+// rememberWorkspace persists the current sort order, filters and view of this
+// instance.
+func (m model) rememberWorkspace() (model, tea.Cmd) {
+	workspaces := make(map[string]Workspace, len(m.settings.Workspaces)+1)
+	for key, workspace := range m.settings.Workspaces {
+		workspaces[key] = workspace
+	}
+	workspaces[workspaceKey(m.httpData)] = Workspace{
+		SortMode:         m.sortMode,
+		DeviceFilter:     m.deviceFilter,
+		FilesystemFilter: m.filesystemFilter,
+		ProblemsOnly:     m.problemsOnly,
+		View:             m.viewMode,
+	}
+	m.settings.Workspaces = workspaces
+	return m, m.saveSettings()
+}
@@ -1,20 +1,176 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
 	zone "github.com/lrstanley/bubblezone"
 	"github.com/pdrolopes/syncthing_TUI/app"
+	"github.com/pdrolopes/syncthing_TUI/styles"
 )
 
 func main() {
+	view := flag.String("view", "default", "initial view: \"default\" or \"traffic\"")
+	accentColor := flag.String("accent-color", "", "accent color as hex, e.g. #ff8800")
+	noTLSWarning := flag.Bool(
+		"no-tls-warning",
+		false,
+		"hide the warning about disabled TLS certificate verification",
+	)
+	syncthingURL := flag.String("url", "", "syncthing address, overrides SYNCTHING_URL")
+	apiKey := flag.String("api-key", "", "syncthing api key, overrides SYNCTHING_API_KEY")
+	auth := flag.String("auth", "apikey", "how the api key is sent: \"apikey\" or \"bearer\"")
+	doctor := flag.Bool("doctor", false, "check the connection to syncthing and exit")
+	dumpConfig := flag.String(
+		"dump-config",
+		"",
+		"write the syncthing config as JSON to this file (\"-\" for stdout) and exit",
+	)
+	problemsOnly := flag.Bool(
+		"problems-only",
+		false,
+		"start showing only the folders and devices needing attention",
+	)
+	replay := flag.String(
+		"replay",
+		"",
+		"step through a messages.log recorded with DEBUG set instead of connecting to syncthing",
+	)
+	rateWindow := flag.Duration(
+		"rate-window",
+		0,
+		"smooth the byte rates over this window, e.g. 30s, 0 shows the rate of the last refresh",
+	)
+	noRedact := flag.Bool("no-redact", false, "keep api key and passwords in --dump-config")
+	testNotify := flag.Bool(
+		"test-notify",
+		false,
+		"ring the terminal bell and send a sample desktop notification, then exit",
+	)
+	flag.Parse()
+
+	if *testNotify {
+		if err := app.TestNotify(os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Fprintln(os.Stderr, "Sent a test notification, check the terminal bell and desktop.")
+		return
+	}
+
+	authMode, err := app.ParseAuthMode(*auth)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+
+	if *syncthingURL != "" {
+		if _, err := app.ParseSyncthingURL(*syncthingURL); err != nil {
+			fmt.Println(err)
+			os.Exit(2)
+		}
+	}
+	// the fallback is only dialed during a failover, catch a bad one now
+	if fallbackURL, hasFallback := os.LookupEnv("SYNCTHING_FALLBACK_URL"); hasFallback {
+		if _, err := app.ParseSyncthingURL(fallbackURL); err != nil {
+			fmt.Println("SYNCTHING_FALLBACK_URL:", err)
+			os.Exit(2)
+		}
+	}
+
+	options := app.Options{
+		Auth:   authMode,
+		URL:    *syncthingURL,
+		APIKey: *apiKey,
+	}
+
+	if *doctor {
+		if !app.RunDoctor(os.Stdout, options) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *dumpConfig != "" {
+		if err := writeConfigDump(*dumpConfig, options, !*noRedact); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *accentColor != "" && !styles.IsHexColor(*accentColor) {
+		fmt.Fprintf(os.Stderr, "invalid --accent-color %q, using the default\n", *accentColor)
+		*accentColor = ""
+	}
+
+	if err := app.ValidateRateWindow(*rateWindow); err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+
+	viewMode, err := app.ParseViewMode(*view)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+
+	options.View = viewMode
+	options.AccentColor = *accentColor
+	options.HideTLSWarning = *noTLSWarning
+	options.ProblemsOnly = *problemsOnly
+	options.RateWindow = *rateWindow
+	var model tea.Model
+	if *replay != "" {
+		model, err = newReplay(*replay, options)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	} else {
+		model = app.NewModel(options)
+	}
+
 	zone.NewGlobal()
-	p := tea.NewProgram(app.NewModel(), tea.WithAltScreen(), tea.WithMouseCellMotion())
+	p := tea.NewProgram(
+		model,
+		tea.WithAltScreen(),
+		tea.WithMouseCellMotion(),
+	)
 
 	if _, err := p.Run(); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 }
+
+func writeConfigDump(path string, options app.Options, redact bool) error {
+	if path == "-" {
+		return app.DumpConfig(os.Stdout, options, redact)
+	}
+
+	// the dump may hold secrets with --no-redact
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if err := app.DumpConfig(file, options, redact); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+func newReplay(path string, options app.Options) (tea.Model, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return app.NewReplay(options, file)
+}
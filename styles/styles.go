@@ -2,6 +2,7 @@ package styles
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -30,3 +31,19 @@ var PositiveBtn = BtnStyleV2.
 var NegativeBtn = BtnStyleV2.
 	Background(ErrorColor).
 	Foreground(lipgloss.Color("#ffffff"))
+
+var hexColorRegexp = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+func IsHexColor(color string) bool {
+	return hexColorRegexp.MatchString(color)
+}
+
+// SetAccentColor overrides AccentColor for both light and dark terminals.
+func SetAccentColor(hex string) error {
+	if !IsHexColor(hex) {
+		return fmt.Errorf("invalid hex color %q", hex)
+	}
+
+	AccentColor = lipgloss.AdaptiveColor{Light: hex, Dark: hex}
+	return nil
+}
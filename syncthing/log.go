@@ -0,0 +1,56 @@
+package syncthing
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+type SystemLog struct {
+	Messages []LogLine `json:"messages"`
+}
+
+type LogLine struct {
+	When    time.Time `json:"when"`
+	Message string    `json:"message"`
+	Level   LogLevel  `json:"level"`
+}
+
+type LogLevel int
+
+const (
+	LogLevelDebug LogLevel = iota
+	LogLevelVerbose
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+)
+
+// UnmarshalJSON accepts the numeric levels of syncthing 1.x and the level names
+// of newer releases.
+func (l *LogLevel) UnmarshalJSON(data []byte) error {
+	var level int
+	if err := json.Unmarshal(data, &level); err == nil {
+		*l = LogLevel(level)
+		return nil
+	}
+
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	switch strings.ToUpper(name) {
+	case "DEBUG":
+		*l = LogLevelDebug
+	case "VERBOSE":
+		*l = LogLevelVerbose
+	case "WARN", "WARNING":
+		*l = LogLevelWarn
+	case "ERROR":
+		*l = LogLevelError
+	default:
+		*l = LogLevelInfo
+	}
+
+	return nil
+}
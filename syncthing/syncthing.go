@@ -166,6 +166,20 @@ type Connection struct {
 	IsLocal       bool        `json:"isLocal"`
 	Crypto        string      `json:"crypto"`
 	Primary       *Connection `json:"primary"`
+	// extra connections to the same device, only set along with Primary
+	Secondary []Connection `json:"secondary"`
+}
+
+// NumConnections counts the primary and secondary connections, older syncthing
+// versions only report the single one.
+func (c Connection) NumConnections() int {
+	if c.Primary == nil {
+		if c.Connected {
+			return 1
+		}
+		return 0
+	}
+	return 1 + len(c.Secondary)
 }
 
 func (c Connection) When() time.Time {
@@ -232,6 +246,13 @@ type IgnoredFolder struct {
 	Label string    `json:"label"`
 }
 
+type SystemUpgrade struct {
+	Latest     string `json:"latest"`
+	MajorNewer bool   `json:"majorNewer"`
+	Newer      bool   `json:"newer"`
+	Running    string `json:"running"`
+}
+
 type SystemVersion struct {
 	Arch        string    `json:"arch"`
 	Codename    string    `json:"codename"`
@@ -437,6 +458,14 @@ type IgnoresDefaults struct {
 	Lines []string `json:"lines"`
 }
 
+// Ignores is the answer of /rest/db/ignores, Ignore is null when the folder
+// has no .stignore.
+type Ignores struct {
+	Ignore   []string `json:"ignore"`
+	Expanded []string `json:"expanded"`
+	Error    string   `json:"error"`
+}
+
 type DiskSpace struct {
 	Value float64 `json:"value"`
 	Unit  string  `json:"unit"`
@@ -465,6 +494,18 @@ type PendingDeviceInfo struct {
 	Address string    `json:"address"`
 }
 
+type PendingFolderInfo struct {
+	// device ID -> offer
+	OfferedBy map[string]PendingFolderOffer `json:"offeredBy"`
+}
+
+type PendingFolderOffer struct {
+	Time             time.Time `json:"time"`
+	Label            string    `json:"label"`
+	ReceiveEncrypted bool      `json:"receiveEncrypted"`
+	RemoteEncrypted  bool      `json:"remoteEncrypted"`
+}
+
 // EVENTS PAYLOAD
 
 type Event[DATA any] struct {
@@ -505,8 +546,59 @@ type PendingDevicesChangedEventData struct {
 	Removed []DeviceChanged `json:"removed"`
 }
 
+type PendingFoldersChangedEventData struct {
+	Added   []FolderChanged `json:"added"`
+	Removed []FolderChanged `json:"removed"`
+}
+
+type FolderChanged struct {
+	DeviceID         string `json:"deviceID"`
+	FolderID         string `json:"folderID"`
+	FolderLabel      string `json:"folderLabel,omitempty"`
+	ReceiveEncrypted bool   `json:"receiveEncrypted,omitempty"`
+	RemoteEncrypted  bool   `json:"remoteEncrypted,omitempty"`
+}
+
 type DeviceChanged struct {
 	Address  string `json:"address,omitempty"`
 	DeviceID string `json:"deviceID"`
 	Name     string `json:"name,omitempty"`
 }
+
+type DeviceConnectedEventData struct {
+	Addr          string `json:"addr"`
+	ID            string `json:"id"`
+	DeviceName    string `json:"deviceName"`
+	ClientName    string `json:"clientName"`
+	ClientVersion string `json:"clientVersion"`
+	Type          string `json:"type"`
+}
+
+type DeviceDisconnectedEventData struct {
+	Error string `json:"error"`
+	ID    string `json:"id"`
+}
+
+// Type is "file", "dir" or "symlink" and Action "update", "metadata" or "delete".
+type ItemStartedEventData struct {
+	Item   string `json:"item"`
+	Folder string `json:"folder"`
+	Type   string `json:"type"`
+	Action string `json:"action"`
+}
+
+// Error is nil when the item was synced.
+type ItemFinishedEventData struct {
+	Item   string  `json:"item"`
+	Folder string  `json:"folder"`
+	Error  *string `json:"error"`
+	Type   string  `json:"type"`
+	Action string  `json:"action"`
+}
+
+// From and To hold the watcher errors, empty when watching works.
+type FolderWatchStateChangedEventData struct {
+	Folder string `json:"folder"`
+	From   string `json:"from,omitempty"`
+	To     string `json:"to,omitempty"`
+}